@@ -0,0 +1,250 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	productusecase "foodie/backend/internal/application/usecase/product"
+	"foodie/backend/internal/domain/product"
+	"foodie/backend/pkg/money"
+)
+
+// csvColumns is the header written on export. On import only name and price
+// are required and columns may come in any order.
+var csvColumns = []string{"id", "category", "name", "price", "stock_mode", "stock", "modifier_groups"}
+
+// parsedRow is one row of an import file; err is set when it could not be read.
+type parsedRow struct {
+	item productusecase.MenuItem
+	err  error
+}
+
+// menuItem is the JSON form of a menu item, also used for the CSV
+// modifier_groups column.
+type menuItem struct {
+	ID             string          `json:"id,omitempty"`
+	Category       string          `json:"category,omitempty"`
+	Name           string          `json:"name"`
+	Price          decimal         `json:"price"`
+	StockMode      string          `json:"stock_mode,omitempty"`
+	Stock          int             `json:"stock,omitempty"`
+	ModifierGroups []modifierGroup `json:"modifier_groups,omitempty"`
+}
+
+type modifierGroup struct {
+	ID            string           `json:"id,omitempty"`
+	Name          string           `json:"name"`
+	Required      bool             `json:"required,omitempty"`
+	MinSelections int              `json:"min_selections,omitempty"`
+	MaxSelections int              `json:"max_selections,omitempty"`
+	Options       []modifierOption `json:"options"`
+}
+
+type modifierOption struct {
+	ID         string      `json:"id,omitempty"`
+	Name       string      `json:"name"`
+	PriceDelta money.Money `json:"price_delta"`
+}
+
+// decimal is a price written either as a JSON string or a JSON number.
+// Exports always write a string so amounts round-trip exactly.
+type decimal string
+
+func (d *decimal) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*d = decimal(s)
+		return nil
+	}
+	*d = decimal(data)
+	return nil
+}
+
+// readJSON reads a JSON array of menu items. Items are decoded one by one so
+// a malformed item is reported on its own row.
+func readJSON(r io.Reader) ([]parsedRow, error) {
+	var raw []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("expected a JSON array of menu items: %w", err)
+	}
+
+	rows := make([]parsedRow, 0, len(raw))
+	for _, data := range raw {
+		var item menuItem
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&item); err != nil {
+			rows = append(rows, parsedRow{err: err})
+			continue
+		}
+		rows = append(rows, parsedRow{item: item.toMenuItem()})
+	}
+	return rows, nil
+}
+
+// writeJSON writes menu items as an indented JSON array.
+func writeJSON(w io.Writer, items []productusecase.MenuItem) error {
+	out := make([]menuItem, 0, len(items))
+	for _, item := range items {
+		out = append(out, fromMenuItem(item))
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
+
+// readCSV reads menu items from CSV with a header row.
+func readCSV(r io.Reader) ([]parsedRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // Short rows are reported per row instead of aborting
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"name", "price"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV header must have a %q column", required)
+		}
+	}
+
+	var rows []parsedRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			// Only quoting errors remain, and they leave the rest of the file unreadable
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		rows = append(rows, parseCSVRecord(record, columns))
+	}
+}
+
+// parseCSVRecord converts one CSV record using the header's column positions.
+func parseCSVRecord(record []string, columns map[string]int) parsedRow {
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	item := menuItem{
+		ID:        field("id"),
+		Category:  field("category"),
+		Name:      field("name"),
+		Price:     decimal(field("price")),
+		StockMode: field("stock_mode"),
+	}
+	if stock := field("stock"); stock != "" {
+		quantity, err := strconv.Atoi(stock)
+		if err != nil {
+			return parsedRow{err: fmt.Errorf("stock must be a whole number, got %q", stock)}
+		}
+		item.Stock = quantity
+	}
+	if groups := field("modifier_groups"); groups != "" {
+		if err := json.Unmarshal([]byte(groups), &item.ModifierGroups); err != nil {
+			return parsedRow{err: fmt.Errorf("modifier_groups must be a JSON array: %w", err)}
+		}
+	}
+	return parsedRow{item: item.toMenuItem()}
+}
+
+// writeCSV writes menu items as CSV; modifier groups are a JSON column.
+func writeCSV(w io.Writer, items []productusecase.MenuItem) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvColumns); err != nil {
+		return err
+	}
+	for _, item := range items {
+		out := fromMenuItem(item)
+		groups := ""
+		if len(out.ModifierGroups) > 0 {
+			data, err := json.Marshal(out.ModifierGroups)
+			if err != nil {
+				return err
+			}
+			groups = string(data)
+		}
+		record := []string{out.ID, out.Category, out.Name, string(out.Price), out.StockMode, strconv.Itoa(out.Stock), groups}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// toMenuItem converts the file format to the use case's.
+func (m menuItem) toMenuItem() productusecase.MenuItem {
+	item := productusecase.MenuItem{
+		ID:        m.ID,
+		Category:  m.Category,
+		Name:      m.Name,
+		Price:     string(m.Price),
+		StockMode: m.StockMode,
+		Stock:     m.Stock,
+	}
+	for _, g := range m.ModifierGroups {
+		group := product.ModifierGroup{
+			ID:            g.ID,
+			Name:          strings.TrimSpace(g.Name),
+			Required:      g.Required,
+			MinSelections: g.MinSelections,
+			MaxSelections: g.MaxSelections,
+		}
+		for _, o := range g.Options {
+			group.Options = append(group.Options, product.ModifierOption{
+				ID:         o.ID,
+				Name:       strings.TrimSpace(o.Name),
+				PriceDelta: o.PriceDelta,
+			})
+		}
+		item.ModifierGroups = append(item.ModifierGroups, group)
+	}
+	return item
+}
+
+// fromMenuItem converts an exported item to the file format.
+func fromMenuItem(item productusecase.MenuItem) menuItem {
+	m := menuItem{
+		ID:        item.ID,
+		Category:  item.Category,
+		Name:      item.Name,
+		Price:     decimal(item.Price),
+		StockMode: item.StockMode,
+		Stock:     item.Stock,
+	}
+	for _, g := range item.ModifierGroups {
+		group := modifierGroup{
+			ID:            g.ID,
+			Name:          g.Name,
+			Required:      g.Required,
+			MinSelections: g.MinSelections,
+			MaxSelections: g.MaxSelections,
+			Options:       make([]modifierOption, 0, len(g.Options)),
+		}
+		for _, o := range g.Options {
+			group.Options = append(group.Options, modifierOption{ID: o.ID, Name: o.Name, PriceDelta: o.PriceDelta})
+		}
+		m.ModifierGroups = append(m.ModifierGroups, group)
+	}
+	return m
+}
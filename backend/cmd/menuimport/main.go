@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	productusecase "foodie/backend/internal/application/usecase/product"
+	"foodie/backend/internal/infrastructure/cache"
+	"foodie/backend/internal/infrastructure/database"
+	"foodie/backend/internal/infrastructure/database/txn"
+	"foodie/backend/pkg/config"
+	"foodie/backend/pkg/money"
+)
+
+// menuimport bulk imports or exports a restaurant's menu.
+//
+//	go run ./cmd/menuimport -restaurant <id> -file menu.csv -dry-run
+//	go run ./cmd/menuimport -command export -restaurant <id> -file menu.json
+func main() {
+	var (
+		command      = flag.String("command", "import", "Command: import, export")
+		restaurantID = flag.String("restaurant", "", "Restaurant ID (required)")
+		file         = flag.String("file", "-", "File to read or write; - for stdin/stdout")
+		format       = flag.String("format", "", "File format: csv, json (default: from the file extension, else csv)")
+		dryRun       = flag.Bool("dry-run", false, "Validate and report without writing anything")
+	)
+	flag.Parse()
+
+	logger := log.New(os.Stderr, "menuimport ", log.LstdFlags)
+
+	if *restaurantID == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	fileFormat := *format
+	if fileFormat == "" {
+		fileFormat = strings.TrimPrefix(strings.ToLower(filepath.Ext(*file)), ".")
+		if fileFormat != "json" {
+			fileFormat = "csv"
+		}
+	}
+	if fileFormat != "csv" && fileFormat != "json" {
+		logger.Fatalf("Unknown format: %s. Use 'csv' or 'json'", fileFormat)
+	}
+
+	// Load environment variables
+	config.Load()
+
+	db, err := database.NewConnectionFromEnv()
+	if err != nil {
+		logger.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	repos, err := database.NewRepositories(db)
+	if err != nil {
+		logger.Fatalf("Failed to initialize repositories: %v", err)
+	}
+
+	// The API's cache, so imported products do not linger stale in it
+	appCache, err := cache.NewCache()
+	if err != nil {
+		logger.Fatalf("Failed to initialize cache: %v", err)
+	}
+	defer appCache.Close()
+
+	productUseCase := productusecase.NewUseCase(productusecase.Dependencies{
+		ProductRepo:   repos.Product,
+		CategoryRepo:  repos.Category,
+		OwnershipRepo: repos.RestaurantOwners,
+		Cache:         appCache,
+		Currency:      config.Get("DEFAULT_CURRENCY", money.DefaultCurrency),
+		TxManager:     txn.NewManager(db),
+	})
+
+	ctx := context.Background()
+	switch *command {
+	case "import":
+		if !runImport(ctx, logger, productUseCase, *restaurantID, *file, fileFormat, *dryRun) {
+			os.Exit(1)
+		}
+	case "export":
+		if err := runExport(ctx, productUseCase, *restaurantID, *file, fileFormat); err != nil {
+			logger.Fatalf("Export failed: %v", err)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command: %s. Use 'import' or 'export'\n", *command)
+		os.Exit(1)
+	}
+}
+
+// runImport reads, validates and imports a menu file, printing one line per
+// rejected row. Rows that cannot be read are reported together with rows the
+// use case rejects; either kind keeps anything from being written.
+func runImport(ctx context.Context, logger *log.Logger, uc productusecase.UseCase, restaurantID, file, format string, dryRun bool) bool {
+	in := os.Stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			logger.Fatalf("Failed to open %s: %v", file, err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	read := readCSV
+	if format == "json" {
+		read = readJSON
+	}
+	rows, err := read(in)
+	if err != nil {
+		logger.Fatalf("Failed to read %s: %v", file, err)
+	}
+
+	// Validate the readable rows, remembering where each came from
+	var items []productusecase.MenuItem
+	var fileRow []int
+	var rejected []productusecase.ImportError
+	for i, row := range rows {
+		if row.err != nil {
+			rejected = append(rejected, productusecase.ImportError{Row: i + 1, Message: row.err.Error()})
+			continue
+		}
+		items = append(items, row.item)
+		fileRow = append(fileRow, i+1)
+	}
+
+	report, err := uc.ImportMenu(ctx, productusecase.ImportMenuCommand{
+		RestaurantID: restaurantID,
+		Items:        items,
+		DryRun:       dryRun || len(rejected) > 0,
+	})
+	if err != nil {
+		logger.Fatalf("Import failed: %v", err)
+	}
+	for _, e := range report.Errors {
+		e.Row = fileRow[e.Row-1]
+		rejected = append(rejected, e)
+	}
+
+	if len(rejected) > 0 {
+		sortByRow(rejected)
+		for _, e := range rejected {
+			if e.Name != "" {
+				logger.Printf("row %d (%s): %s", e.Row, e.Name, e.Message)
+			} else {
+				logger.Printf("row %d: %s", e.Row, e.Message)
+			}
+		}
+		logger.Printf("%d of %d rows rejected; nothing was imported", len(rejected), len(rows))
+		return false
+	}
+
+	verb := "Imported"
+	if dryRun {
+		verb = "Dry run: would import"
+	}
+	logger.Printf("%s %d rows: %d created, %d updated, %d new categories",
+		verb, len(rows), report.Created, report.Updated, report.CategoriesCreated)
+	return true
+}
+
+// runExport writes a restaurant's menu in the given format.
+func runExport(ctx context.Context, uc productusecase.UseCase, restaurantID, file, format string) error {
+	items, err := uc.ExportMenu(ctx, restaurantID)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if file != "-" {
+		f, err := os.Create(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if format == "json" {
+		return writeJSON(out, items)
+	}
+	return writeCSV(out, items)
+}
+
+// sortByRow orders errors by file row; data row numbers exclude any CSV header.
+func sortByRow(errors []productusecase.ImportError) {
+	sort.SliceStable(errors, func(i, j int) bool { return errors[i].Row < errors[j].Row })
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	productusecase "foodie/backend/internal/application/usecase/product"
+	"foodie/backend/internal/infrastructure/cache"
+	"foodie/backend/internal/infrastructure/database"
+	"foodie/backend/internal/infrastructure/scheduler"
+	"foodie/backend/internal/infrastructure/scheduler/tasks"
+	"foodie/backend/pkg/config"
+	"foodie/backend/pkg/money"
+)
+
+func main() {
+	logger := log.New(os.Stdout, "scheduler ", log.LstdFlags|log.Lshortfile)
+
+	// Load environment variables
+	config.Load()
+
+	// Initialize database connection
+	db, err := database.NewConnectionFromEnv()
+	if err != nil {
+		logger.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	// Initialize repositories
+	repos, err := database.NewRepositories(db)
+	if err != nil {
+		logger.Fatalf("Failed to initialize repositories: %v", err)
+	}
+
+	// The API's cache, so repriced and refilled products do not linger stale in it
+	appCache, err := cache.NewCache()
+	if err != nil {
+		logger.Fatalf("Failed to initialize cache: %v", err)
+	}
+	defer appCache.Close()
+
+	productUseCase := productusecase.NewUseCase(productusecase.Dependencies{
+		ProductRepo:   repos.Product,
+		CategoryRepo:  repos.Category,
+		OwnershipRepo: repos.RestaurantOwners,
+		Cache:         appCache,
+		Currency:      config.Get("DEFAULT_CURRENCY", money.DefaultCurrency),
+	})
+
+	// Create scheduler
+	sched := scheduler.NewScheduler(logger)
+
+	// Register scheduled tasks
+	registerTasks(sched, repos, productUseCase, logger)
+
+	// Setup graceful shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Start scheduler
+	sched.Start()
+
+	logger.Printf("Scheduler started. Registered tasks: %v", sched.ListTasks())
+
+	// Wait for interrupt signal
+	<-ctx.Done()
+	logger.Println("Shutdown signal received")
+
+	// Stop scheduler
+	sched.Stop()
+	logger.Println("Scheduler stopped")
+}
+
+// registerTasks registers all scheduled tasks.
+func registerTasks(sched *scheduler.Scheduler, repos *database.Repositories, products productusecase.UseCase, logger *log.Logger) {
+	// Health check every 5 minutes
+	healthTask := tasks.NewHealthCheckTask(logger)
+	if err := sched.AddTask("0 */5 * * * *", healthTask); err != nil {
+		logger.Printf("Failed to register health check task: %v", err)
+	}
+
+	// Order cleanup every day at 2 AM
+	orderCleanupTask := tasks.NewOrderCleanupTask(repos.Order, logger)
+	if err := sched.AddTask("0 0 2 * * *", orderCleanupTask); err != nil {
+		logger.Printf("Failed to register order cleanup task: %v", err)
+	}
+
+	// Cleanup completed orders every Sunday at 3 AM
+	completedOrdersTask := tasks.NewCleanupCompletedOrdersTask(repos.Order, logger)
+	if err := sched.AddTask("0 0 3 * * 0", completedOrdersTask); err != nil {
+		logger.Printf("Failed to register completed orders cleanup task: %v", err)
+	}
+
+	// Refill daily product quotas every day at midnight (server time by default)
+	stockResetTask := tasks.NewDailyStockResetTask(products, logger)
+	if err := sched.AddTask(config.Get("DAILY_STOCK_RESET_CRON", "0 0 0 * * *"), stockResetTask); err != nil {
+		logger.Printf("Failed to register daily stock reset task: %v", err)
+	}
+
+	// Activate scheduled price changes every minute; reads already resolve the
+	// effective price, this catches up the stored price and the caches
+	priceActivationTask := tasks.NewPriceActivationTask(products, logger)
+	if err := sched.AddTask(config.Get("PRICE_ACTIVATION_CRON", "0 * * * * *"), priceActivationTask); err != nil {
+		logger.Printf("Failed to register price activation task: %v", err)
+	}
+
+	// TODO: Add more tasks as needed:
+	// - Send order reminders
+	// - Update order statuses (auto-complete after delivery time)
+	// - Generate daily reports
+	// - Sync with external services
+}
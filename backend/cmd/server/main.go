@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	authusecase "foodie/backend/internal/application/usecase/auth"
+	orderusecase "foodie/backend/internal/application/usecase/order"
+	productusecase "foodie/backend/internal/application/usecase/product"
+	restaurantusecase "foodie/backend/internal/application/usecase/restaurant"
+	searchusecase "foodie/backend/internal/application/usecase/search"
+	"foodie/backend/internal/domain/order"
+	"foodie/backend/internal/infrastructure/cache"
+	"foodie/backend/internal/infrastructure/database"
+	"foodie/backend/internal/infrastructure/database/txn"
+	"foodie/backend/internal/infrastructure/external"
+	"foodie/backend/internal/infrastructure/messaging"
+	"foodie/backend/internal/infrastructure/storage"
+	"foodie/backend/internal/interfaces/http/controller"
+	"foodie/backend/internal/interfaces/http/router"
+	"foodie/backend/pkg/config"
+	"foodie/backend/pkg/jwt"
+	"foodie/backend/pkg/logger"
+	"foodie/backend/pkg/money"
+
+	"go.uber.org/zap"
+)
+
+func main() {
+	// Load environment variables
+	if err := config.Load(); err != nil {
+		panic("failed to load config: " + err.Error())
+	}
+
+	// Initialize structured logger with JSON output (Grafana/Loki compatible)
+	logConfig := logger.Config{
+		Level:  config.Get("LOG_LEVEL", "info"),
+		Format: config.Get("LOG_FORMAT", "json"),
+		Output: config.Get("LOG_OUTPUT", "stdout"),
+	}
+	appLogger, err := logger.New(logConfig)
+	if err != nil {
+		panic("failed to initialize logger: " + err.Error())
+	}
+	defer appLogger.Sync()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Initialize database connection and repositories
+	db, err := database.NewConnectionFromEnv()
+	if err != nil {
+		appLogger.Fatal("database_connection_failed", zap.Error(err))
+	}
+	defer db.Close()
+
+	repos, err := database.NewRepositories(db)
+	if err != nil {
+		appLogger.Fatal("repositories_init_failed", zap.Error(err))
+	}
+
+	appCache, err := cache.NewCache()
+	if err != nil {
+		appLogger.Fatal("cache_init_failed", zap.Error(err))
+	}
+	defer appCache.Close()
+
+	blobStorage, err := storage.NewBlobStorage()
+	if err != nil {
+		appLogger.Fatal("storage_init_failed", zap.Error(err))
+	}
+
+	paymentGateway, err := external.NewPaymentGateway()
+	if err != nil {
+		appLogger.Fatal("payment_gateway_init_failed", zap.Error(err))
+	}
+
+	// Delivery quoting: distance and ETA from the maps service, priced by the fee schedule
+	mapsService, err := external.NewMapsService()
+	if err != nil {
+		appLogger.Fatal("maps_service_init_failed", zap.Error(err))
+	}
+	currency := config.Get("DEFAULT_CURRENCY", money.DefaultCurrency)
+	if !money.ValidCurrency(currency) {
+		appLogger.Fatal("default_currency_invalid", zap.String("currency", currency))
+	}
+	deliveryFees, err := order.ParseFeeSchedule(config.Get("DELIVERY_FEE_SCHEDULE", order.DefaultFeeSchedule), currency)
+	if err != nil {
+		appLogger.Fatal("delivery_fee_schedule_invalid", zap.Error(err))
+	}
+	promotions, err := order.ParsePromotions(config.Get("PROMO_CODES", ""), currency)
+	if err != nil {
+		appLogger.Fatal("promo_codes_invalid", zap.Error(err))
+	}
+	// Default time zone of restaurants; availability windows ("breakfast
+	// 07:00-11:00") on menus and in search are read in it
+	location, err := time.LoadLocation(config.Get("RESTAURANT_TIMEZONE", "UTC"))
+	if err != nil {
+		appLogger.Fatal("restaurant_timezone_invalid", zap.Error(err))
+	}
+	quoteSecret := []byte(config.Get("QUOTE_SIGNING_SECRET", ""))
+	if len(quoteSecret) == 0 {
+		// Quotes signed with a per-process key cannot be redeemed on other
+		// instances or after a restart, so set QUOTE_SIGNING_SECRET in production.
+		quoteSecret = make([]byte, 32)
+		if _, err := rand.Read(quoteSecret); err != nil {
+			appLogger.Fatal("quote_secret_generation_failed", zap.Error(err))
+		}
+		appLogger.Warn("quote_signing_secret_not_set")
+	}
+
+	// Access tokens are signed with RS256 when JWT_PRIVATE_KEY_FILE is set and
+	// with HS256 and JWT_SECRET otherwise; like the quote secret, the key must
+	// be shared by every instance or tokens only work where they were issued.
+	jwtKeyID := config.Get("JWT_KEY_ID", "")
+	var tokenSigner jwt.Signer
+	if keyFile := config.Get("JWT_PRIVATE_KEY_FILE", ""); keyFile != "" {
+		pemData, err := os.ReadFile(keyFile)
+		if err != nil {
+			appLogger.Fatal("jwt_private_key_read_failed", zap.Error(err))
+		}
+		privateKey, err := jwt.ParseRSAPrivateKeyPEM(pemData)
+		if err != nil {
+			appLogger.Fatal("jwt_private_key_invalid", zap.Error(err))
+		}
+		tokenSigner = jwt.NewRS256Signer(jwtKeyID, privateKey)
+	} else {
+		jwtSecret := []byte(config.Get("JWT_SECRET", ""))
+		if len(jwtSecret) == 0 {
+			jwtSecret = make([]byte, 32)
+			if _, err := rand.Read(jwtSecret); err != nil {
+				appLogger.Fatal("jwt_secret_generation_failed", zap.Error(err))
+			}
+			appLogger.Warn("jwt_secret_not_set")
+		}
+		tokenSigner = jwt.NewHS256Signer(jwtKeyID, jwtSecret)
+	}
+	// Tokens are accepted if signed with the key above or, for rotation and
+	// tokens issued elsewhere, a key from the JWKS file
+	tokenKeys := jwt.KeySources{jwt.KeySet{tokenSigner.Key()}}
+	if jwksFile := config.Get("JWT_JWKS_FILE", ""); jwksFile != "" {
+		refresh := time.Duration(config.GetInt("JWT_JWKS_REFRESH_SECONDS", 60)) * time.Second
+		jwks, err := jwt.LoadJWKSFile(jwksFile, refresh)
+		if err != nil {
+			appLogger.Fatal("jwt_jwks_load_failed", zap.Error(err))
+		}
+		tokenKeys = append(tokenKeys, jwks)
+	}
+	jwtIssuer := config.Get("JWT_ISSUER", "foodie")
+	jwtAudience := config.Get("JWT_AUDIENCE", "foodie-api")
+	tokenVerifier := jwt.NewVerifier(jwt.VerifierConfig{
+		Keys:     tokenKeys,
+		Issuer:   jwtIssuer,
+		Audience: jwtAudience,
+		Leeway:   time.Duration(config.GetInt("JWT_LEEWAY_SECONDS", 30)) * time.Second,
+	})
+
+	// Domain events are written to the outbox table in the same transaction as
+	// the business change; `worker outbox` relays them to the message broker.
+	eventPublisher := messaging.NewOutboxPublisher(db, nil)
+
+	// Initialize use cases with repositories
+	productUseCase := productusecase.NewUseCase(productusecase.Dependencies{
+		ProductRepo:   repos.Product,
+		CategoryRepo:  repos.Category,
+		OwnershipRepo: repos.RestaurantOwners,
+		Cache:         appCache,
+		Currency:      currency,
+		Images:        blobStorage,
+		MaxImageBytes: int64(config.GetInt("PRODUCT_IMAGE_MAX_BYTES", 5<<20)),
+		ThumbnailSize: config.GetInt("PRODUCT_THUMBNAIL_SIZE", 320),
+		TxManager:     txn.NewManager(db),
+		Location:      location,
+	})
+	orderUseCase := orderusecase.NewUseCase(orderusecase.Dependencies{
+		OrderRepo:      repos.Order,
+		ProductRepo:    repos.Product,
+		CategoryRepo:   repos.Category,
+		PaymentRepo:    repos.Payment,
+		OwnershipRepo:  repos.RestaurantOwners,
+		PaymentGateway: paymentGateway,
+		MapsService:    mapsService,
+		RestaurantRepo: repos.Restaurant,
+		DeliveryFees:   deliveryFees,
+		PrepTime:       time.Duration(config.GetInt("ORDER_PREP_TIME_MINUTES", 15)) * time.Minute,
+		Pricing: order.PricingPolicy{
+			ServiceFeePercent: config.GetFloat("SERVICE_FEE_PERCENT", 0),
+			TaxPercent:        config.GetFloat("TAX_PERCENT", 0),
+			Promotions:        promotions,
+		},
+		Currency:     currency,
+		QuoteSecret:  quoteSecret,
+		QuoteTTL:     time.Duration(config.GetInt("QUOTE_TTL_MINUTES", 10)) * time.Minute,
+		TxManager:    txn.NewManager(db),
+		Publisher:    eventPublisher,
+		ProductCache: productUseCase,
+	})
+
+	searchUseCase := searchusecase.NewUseCase(searchusecase.Dependencies{
+		ProductRepo:    repos.Product,
+		RestaurantRepo: repos.Restaurant,
+		Currency:       currency,
+		Location:       location,
+	})
+	authUseCase := authusecase.NewUseCase(authusecase.Dependencies{
+		UserRepo: repos.User,
+		Signer:   tokenSigner,
+		Issuer:   jwtIssuer,
+		Audience: jwtAudience,
+		TokenTTL: time.Duration(config.GetInt("ACCESS_TOKEN_TTL_MINUTES", 60)) * time.Minute,
+	})
+	restaurantUseCase := restaurantusecase.NewUseCase(restaurantusecase.Dependencies{
+		RestaurantRepo: repos.Restaurant,
+		OwnershipRepo:  repos.RestaurantOwners,
+		Location:       location,
+	})
+
+	// Initialize controllers
+	healthController := controller.NewHealthController()
+	orderController := controller.NewOrderController(orderUseCase)
+	productController := controller.NewProductController(productUseCase)
+	menuController := controller.NewMenuController(productUseCase)
+	searchController := controller.NewSearchController(searchUseCase)
+	restaurantController := controller.NewRestaurantController(restaurantUseCase)
+	authController := controller.NewAuthController(authUseCase)
+
+	// Setup router with logger and controllers
+	httpRouter := router.NewRouter(appLogger, appCache, tokenVerifier, healthController, orderController, productController, menuController, searchController, restaurantController, authController)
+	httpRouter.SetupRoutes()
+	// Local storage serves uploads itself; other backends hand out their own URLs
+	if local, ok := blobStorage.(*storage.LocalStorage); ok {
+		httpRouter.Mount(local.PathPrefix(), local)
+	}
+
+	// Server address - can use SERVER_ADDR or combine SERVER_HOST + SERVER_PORT
+	serverAddr := config.Get("SERVER_ADDR", "")
+	if serverAddr == "" {
+		host := config.Get("SERVER_HOST", "0.0.0.0")
+		port := config.Get("SERVER_PORT", "8080")
+		serverAddr = host + ":" + port
+	}
+	server := &http.Server{
+		Addr:    serverAddr,
+		Handler: httpRouter,
+	}
+
+	go func() {
+		appLogger.Info("server_starting",
+			zap.String("addr", serverAddr),
+		)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			appLogger.Fatal("server_error",
+				zap.Error(err),
+			)
+		}
+	}()
+
+	<-ctx.Done()
+	appLogger.Info("shutdown_signal_received")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*1e9)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		appLogger.Error("graceful_shutdown_failed",
+			zap.Error(err),
+		)
+	}
+}
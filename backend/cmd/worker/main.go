@@ -10,7 +10,9 @@ import (
 	"syscall"
 	"time"
 
+	"foodie/backend/internal/infrastructure/database"
 	"foodie/backend/internal/infrastructure/messaging"
+	"foodie/backend/pkg/config"
 )
 
 // WorkerConfig holds configuration for the worker.
@@ -29,9 +31,17 @@ func main() {
 		logger.Fatalf("Examples:")
 		logger.Fatalf("  %s order", os.Args[0])
 		logger.Fatalf("  %s notification notification_queue notification.*", os.Args[0])
+		logger.Fatalf("  %s outbox", os.Args[0])
 	}
 
 	workerType := os.Args[1]
+
+	// The outbox relay publishes instead of consuming, so it has its own loop
+	if workerType == "outbox" {
+		runOutboxRelay(logger)
+		return
+	}
+
 	queueName := getEnvOrDefault("QUEUE_NAME", fmt.Sprintf("%s_queue", workerType))
 	routingPattern := getEnvOrDefault("ROUTING_PATTERN", fmt.Sprintf("%s.*", workerType))
 
@@ -87,6 +97,42 @@ func main() {
 	logger.Println("Worker stopped")
 }
 
+// runOutboxRelay forwards events recorded in the outbox table to the message broker
+// until a shutdown signal is received.
+func runOutboxRelay(logger *log.Logger) {
+	config.Load()
+
+	db, err := database.NewConnectionFromEnv()
+	if err != nil {
+		logger.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	publisher, err := messaging.NewPublisher()
+	if err != nil {
+		logger.Fatalf("Failed to create publisher: %v", err)
+	}
+	if closer, ok := publisher.(*messaging.RabbitMQPublisher); ok {
+		defer func() {
+			if err := closer.Close(); err != nil {
+				logger.Printf("Error closing publisher: %v", err)
+			}
+		}()
+	}
+
+	interval := time.Duration(config.GetInt("OUTBOX_POLL_INTERVAL_MS", 1000)) * time.Millisecond
+	relay := messaging.NewOutboxPublisher(db, publisher)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	logger.Printf("Outbox relay started (poll interval: %s)", interval)
+	if err := relay.Run(ctx, interval); err != nil && err != context.Canceled {
+		logger.Printf("Outbox relay stopped with error: %v", err)
+	}
+	logger.Println("Outbox relay stopped")
+}
+
 // createHandler creates a handler function based on worker type.
 func createHandler(workerType string, logger *log.Logger) messaging.ConsumerHandler {
 	switch workerType {
@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"foodie/backend/internal/domain/user"
+	"foodie/backend/pkg/jwt"
+)
+
+// UseCase defines use cases for user accounts and sign-in.
+type UseCase interface {
+	// Register creates an account and signs it in.
+	// Returns user.ErrEmailTaken if the email already has an account.
+	Register(ctx context.Context, cmd RegisterCommand) (*Session, error)
+
+	// Login checks an email and password and issues an access token.
+	// Returns ErrInvalidCredentials for an unknown email or a wrong password alike.
+	Login(ctx context.Context, cmd LoginCommand) (*Session, error)
+}
+
+// ErrInvalidCredentials is returned when an email and password do not match an account.
+var ErrInvalidCredentials = errors.New("invalid email or password")
+
+// RegisterCommand represents the command to create an account.
+type RegisterCommand struct {
+	Email    string
+	Password string
+	Name     string
+	Role     string // user (default) or restaurant; admins cannot register
+}
+
+// LoginCommand represents the command to sign in.
+type LoginCommand struct {
+	Email    string
+	Password string
+}
+
+// Session is a signed-in user and the access token to send as a Bearer token.
+type Session struct {
+	User        *user.User
+	AccessToken string
+	ExpiresAt   time.Time
+}
+
+// TokenSigner signs access tokens, e.g. jwt.HMACSigner.
+type TokenSigner interface {
+	Sign(claims jwt.Claims) (string, error)
+}
@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"foodie/backend/internal/domain/user"
+	"foodie/backend/pkg/jwt"
+
+	"github.com/google/uuid"
+)
+
+// Dependencies groups the collaborators of the auth use case.
+type Dependencies struct {
+	UserRepo user.Repository
+	// Signer signs access tokens; Issuer and Audience are written into them
+	// and must match what the API checks.
+	Signer   TokenSigner
+	Issuer   string
+	Audience string
+	// TokenTTL is how long an access token is valid. Default: 1 hour.
+	TokenTTL time.Duration
+}
+
+// useCaseImpl implements the UseCase interface.
+type useCaseImpl struct {
+	userRepo user.Repository
+	signer   TokenSigner
+	issuer   string
+	audience string
+	tokenTTL time.Duration
+}
+
+// NewUseCase creates a new auth use case.
+func NewUseCase(deps Dependencies) UseCase {
+	tokenTTL := deps.TokenTTL
+	if tokenTTL <= 0 {
+		tokenTTL = time.Hour
+	}
+	return &useCaseImpl{
+		userRepo: deps.UserRepo,
+		signer:   deps.Signer,
+		issuer:   deps.Issuer,
+		audience: deps.Audience,
+		tokenTTL: tokenTTL,
+	}
+}
+
+// Register creates a customer or restaurant account and signs it in.
+func (uc *useCaseImpl) Register(ctx context.Context, cmd RegisterCommand) (*Session, error) {
+	role := user.Role(cmd.Role)
+	if role == "" {
+		role = user.RoleCustomer
+	}
+	if role != user.RoleCustomer && role != user.RoleRestaurant {
+		return nil, fmt.Errorf("validation failed: role must be %q or %q", user.RoleCustomer, user.RoleRestaurant)
+	}
+
+	now := time.Now()
+	u := &user.User{
+		ID:        uuid.New().String(),
+		Email:     user.NormalizeEmail(cmd.Email),
+		Name:      strings.TrimSpace(cmd.Name),
+		Role:      role,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := u.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if err := user.ValidatePassword(cmd.Password); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	hash, err := user.HashPassword(cmd.Password)
+	if err != nil {
+		return nil, err
+	}
+	u.PasswordHash = hash
+	if err := uc.userRepo.Save(ctx, u); err != nil {
+		if errors.Is(err, user.ErrEmailTaken) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+
+	return uc.issue(u, now)
+}
+
+// Login checks the password of the account with the given email. Unknown
+// emails cost as much as wrong passwords, so responses do not reveal which
+// emails are registered.
+func (uc *useCaseImpl) Login(ctx context.Context, cmd LoginCommand) (*Session, error) {
+	// No account has a password this long; refuse before doing any hashing work
+	if cmd.Email == "" || cmd.Password == "" || utf8.RuneCountInString(cmd.Password) > user.MaxPasswordLength {
+		return nil, ErrInvalidCredentials
+	}
+
+	u, err := uc.userRepo.FindByEmail(ctx, user.NormalizeEmail(cmd.Email))
+	if errors.Is(err, user.ErrNotFound) {
+		user.CheckPasswordHash(dummyPasswordHash(), cmd.Password)
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+	if !u.CheckPassword(cmd.Password) {
+		return nil, ErrInvalidCredentials
+	}
+
+	return uc.issue(u, time.Now())
+}
+
+// issue signs an access token for u, valid from now for the token TTL.
+func (uc *useCaseImpl) issue(u *user.User, now time.Time) (*Session, error) {
+	expiresAt := now.Add(uc.tokenTTL)
+	claims := jwt.Claims{
+		Subject:   u.ID,
+		Role:      string(u.Role),
+		Issuer:    uc.issuer,
+		IssuedAt:  now.Unix(),
+		NotBefore: now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+		ID:        uuid.New().String(),
+	}
+	if uc.audience != "" {
+		claims.Audience = jwt.Audience{uc.audience}
+	}
+
+	token, err := uc.signer.Sign(claims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue access token: %w", err)
+	}
+	return &Session{User: u, AccessToken: token, ExpiresAt: time.Unix(claims.ExpiresAt, 0)}, nil
+}
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash     string
+)
+
+// dummyPasswordHash is checked against when the email is unknown, so a
+// failed login takes as long whether or not the account exists.
+func dummyPasswordHash() string {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = user.HashPassword(uuid.New().String())
+	})
+	return dummyHash
+}
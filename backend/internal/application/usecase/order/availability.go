@@ -0,0 +1,93 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"foodie/backend/internal/domain/category"
+	"foodie/backend/internal/domain/order"
+	productrepo "foodie/backend/internal/domain/product"
+	"foodie/backend/internal/domain/restaurant"
+	timeutils "foodie/backend/pkg/utils/time"
+)
+
+// openRestaurant loads the restaurant an order is for and checks it takes
+// orders at t. Unknown restaurants return restaurant.ErrNotFound.
+func (uc *useCaseImpl) openRestaurant(ctx context.Context, restaurantID string, t time.Time) (*restaurant.Restaurant, error) {
+	rest, err := uc.restaurantRepo.FindByID(ctx, restaurantID)
+	if errors.Is(err, restaurant.ErrNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch restaurant: %w", err)
+	}
+
+	switch {
+	case rest.Status != restaurant.StatusOpen:
+		return nil, fmt.Errorf("validation failed: %s is not taking orders right now", rest.Name)
+	case !rest.IsOpenAt(t):
+		return nil, fmt.Errorf("validation failed: %s is closed; it takes orders %s (%s)",
+			rest.Name, describeWindows(rest.OpeningHours), rest.TimeZone)
+	}
+	return rest, nil
+}
+
+// unavailableReason explains why p cannot be ordered at t, by its own or its
+// category's availability windows read in loc, or returns "" when it can be.
+func (uc *useCaseImpl) unavailableReason(ctx context.Context, p *productrepo.Product, t time.Time, loc *time.Location) (string, error) {
+	if !p.IsAvailableAt(t, loc) {
+		return describeUnavailable(p.Name, p.Availability, loc), nil
+	}
+	if p.CategoryID == "" || uc.categoryRepo == nil {
+		return "", nil
+	}
+
+	c, err := uc.categoryRepo.FindByID(ctx, p.CategoryID)
+	if errors.Is(err, category.ErrNotFound) {
+		return "", nil // Uncategorized since the product was read
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch category: %w", err)
+	}
+	if !c.IsAvailableAt(t, loc) {
+		return describeUnavailable(p.Name, c.Availability, loc), nil
+	}
+	return "", nil
+}
+
+// describeUnavailable tells the customer when an item can be ordered instead.
+func describeUnavailable(name string, windows []timeutils.Window, loc *time.Location) string {
+	return fmt.Sprintf("%q is not available at this time; it can be ordered %s (%s)",
+		name, describeWindows(windows), loc)
+}
+
+// describeWindows lists windows for a message, e.g. "mon,tue 07:00-11:00, sat 08:00-12:00".
+func describeWindows(windows []timeutils.Window) string {
+	spans := make([]string, 0, len(windows))
+	for _, w := range windows {
+		spans = append(spans, w.String())
+	}
+	return strings.Join(spans, ", ")
+}
+
+// checkQuotedAvailability rejects a quote whose items have gone out of their
+// availability windows since it was issued, e.g. breakfast quoted at 10:58.
+func (uc *useCaseImpl) checkQuotedAvailability(ctx context.Context, rest *restaurant.Restaurant, items []order.OrderItem, t time.Time) error {
+	for i, item := range items {
+		product, err := uc.productRepo.FindByID(ctx, item.ProductID)
+		if err != nil {
+			return fmt.Errorf("product not found: %s: %w", item.ProductID, err)
+		}
+		reason, err := uc.unavailableReason(ctx, product, t, rest.Zone())
+		if err != nil {
+			return err
+		}
+		if reason != "" {
+			return fmt.Errorf("validation failed: items[%d]: %s", i, reason)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,342 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"foodie/backend/internal/domain/order"
+	"foodie/backend/internal/domain/payment"
+	productrepo "foodie/backend/internal/domain/product"
+	"foodie/backend/internal/domain/restaurant"
+	"foodie/backend/internal/infrastructure/external"
+	"foodie/backend/internal/infrastructure/messaging"
+	"foodie/backend/pkg/money"
+)
+
+// The payment flow runs against the mock gateway and in-memory fakes of the
+// repositories; the embedded interfaces panic if the use case calls anything
+// the flow is not expected to use.
+
+type fakeOrderRepo struct {
+	order.Repository
+	orders  map[string]order.Order
+	history []order.StatusChange
+	// stale, when set, is returned by FindByID instead of the stored order,
+	// as a request that read the order before a concurrent write would see it.
+	stale *order.Order
+}
+
+func (r *fakeOrderRepo) Save(ctx context.Context, o *order.Order) error {
+	r.orders[o.ID] = *o
+	return nil
+}
+
+func (r *fakeOrderRepo) Update(ctx context.Context, o *order.Order, previous order.OrderStatus) error {
+	if r.orders[o.ID].Status != previous {
+		return order.ErrStatusChanged
+	}
+	r.orders[o.ID] = *o
+	return nil
+}
+
+func (r *fakeOrderRepo) FindByID(ctx context.Context, id string) (*order.Order, error) {
+	if r.stale != nil && r.stale.ID == id {
+		o := *r.stale
+		return &o, nil
+	}
+	o, ok := r.orders[id]
+	if !ok {
+		return nil, errors.New("order not found")
+	}
+	return &o, nil
+}
+
+func (r *fakeOrderRepo) SaveStatusChange(ctx context.Context, change *order.StatusChange) error {
+	r.history = append(r.history, *change)
+	return nil
+}
+
+type fakePaymentRepo struct {
+	payment.Repository
+	byOrder map[string]payment.Payment
+}
+
+func (r *fakePaymentRepo) Save(ctx context.Context, p *payment.Payment) error {
+	r.byOrder[p.OrderID] = *p
+	return nil
+}
+
+func (r *fakePaymentRepo) Update(ctx context.Context, p *payment.Payment) error {
+	r.byOrder[p.OrderID] = *p
+	return nil
+}
+
+func (r *fakePaymentRepo) FindByOrderID(ctx context.Context, orderID string) (*payment.Payment, error) {
+	p, ok := r.byOrder[orderID]
+	if !ok {
+		return nil, payment.ErrNotFound
+	}
+	return &p, nil
+}
+
+type fakeProductRepo struct {
+	productrepo.Repository
+	products map[string]productrepo.Product
+}
+
+func (r *fakeProductRepo) FindByID(ctx context.Context, id string) (*productrepo.Product, error) {
+	p, ok := r.products[id]
+	if !ok {
+		return nil, productrepo.ErrNotFound
+	}
+	return &p, nil
+}
+
+func (r *fakeProductRepo) ReserveStock(ctx context.Context, productID string, quantity int) error {
+	p := r.products[productID]
+	if p.Stock.Available < quantity {
+		return &productrepo.OutOfStockError{ProductID: productID, Requested: quantity}
+	}
+	p.Stock.Available -= quantity
+	r.products[productID] = p
+	return nil
+}
+
+func (r *fakeProductRepo) ReleaseStock(ctx context.Context, productID string, quantity int) error {
+	p := r.products[productID]
+	p.Stock.Available += quantity
+	r.products[productID] = p
+	return nil
+}
+
+type fakeRestaurantRepo struct {
+	restaurant.Repository
+	rest restaurant.Restaurant
+}
+
+func (r *fakeRestaurantRepo) FindByID(ctx context.Context, id string) (*restaurant.Restaurant, error) {
+	if id != r.rest.ID {
+		return nil, restaurant.ErrNotFound
+	}
+	rest := r.rest
+	return &rest, nil
+}
+
+// recordingGateway records the idempotency keys of captures and refunds.
+type recordingGateway struct {
+	*external.MockPaymentGateway
+	captures []string
+	refunds  []string
+}
+
+func (g *recordingGateway) CapturePayment(ctx context.Context, paymentID string, amount money.Money, idempotencyKey string) error {
+	g.captures = append(g.captures, idempotencyKey)
+	return g.MockPaymentGateway.CapturePayment(ctx, paymentID, amount, idempotencyKey)
+}
+
+func (g *recordingGateway) RefundPayment(ctx context.Context, paymentID string, amount money.Money, idempotencyKey string) error {
+	g.refunds = append(g.refunds, idempotencyKey)
+	return g.MockPaymentGateway.RefundPayment(ctx, paymentID, amount, idempotencyKey)
+}
+
+// inlineTx runs transactions without a database.
+type inlineTx struct{}
+
+func (inlineTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type paymentFlow struct {
+	useCase  UseCase
+	orders   *fakeOrderRepo
+	payments *fakePaymentRepo
+	products *fakeProductRepo
+	gateway  *recordingGateway
+}
+
+func newPaymentFlow(t *testing.T, gatewaySucceeds bool) *paymentFlow {
+	t.Helper()
+	fees, err := order.ParseFeeSchedule(order.DefaultFeeSchedule, "USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &paymentFlow{
+		orders:   &fakeOrderRepo{orders: map[string]order.Order{}},
+		payments: &fakePaymentRepo{byOrder: map[string]payment.Payment{}},
+		gateway:  &recordingGateway{MockPaymentGateway: external.NewMockPaymentGateway(gatewaySucceeds)},
+		products: &fakeProductRepo{products: map[string]productrepo.Product{
+			"pho": {
+				ID:           "pho",
+				RestaurantID: "r1",
+				Name:         "Pho",
+				Price:        money.MustParse("8.50", "USD"),
+				Stock:        productrepo.Stock{Mode: productrepo.StockCount, Available: 5},
+			},
+		}},
+	}
+	f.useCase = NewUseCase(Dependencies{
+		OrderRepo:      f.orders,
+		ProductRepo:    f.products,
+		PaymentRepo:    f.payments,
+		RestaurantRepo: &fakeRestaurantRepo{rest: restaurant.Restaurant{ID: "r1", Name: "Pho 24", Status: restaurant.StatusOpen}},
+		PaymentGateway: f.gateway,
+		MapsService:    external.NewMockMapsService(),
+		DeliveryFees:   fees,
+		Currency:       "USD",
+		TxManager:      inlineTx{},
+		Publisher:      messaging.NewInMemoryPublisher(),
+	})
+	return f
+}
+
+func (f *paymentFlow) placeOrder(t *testing.T) (*order.Order, error) {
+	t.Helper()
+	return f.useCase.CreateOrder(context.Background(), CreateOrderCommand{
+		UserID:          "customer-1",
+		RestaurantID:    "r1",
+		Items:           []OrderItemCommand{{ProductID: "pho", Quantity: 2}},
+		PaymentMethod:   "card",
+		DeliveryAddress: "1 Le Loi, District 1",
+	})
+}
+
+func TestPaymentAuthorizedOnCreateAndCapturedOnCompletion(t *testing.T) {
+	f := newPaymentFlow(t, true)
+	ctx := context.Background()
+
+	o, err := f.placeOrder(t)
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if o.Status != order.StatusPending || o.PaymentStatus != order.PaymentAuthorized {
+		t.Fatalf("after create: status %s, payment %s; want pending, authorized", o.Status, o.PaymentStatus)
+	}
+	if got := f.payments.byOrder[o.ID].Status; got != payment.StatusAuthorized {
+		t.Fatalf("payment status %s, want authorized", got)
+	}
+	if got := f.products.products["pho"].Stock.Available; got != 3 {
+		t.Fatalf("stock %d after ordering 2 of 5, want 3", got)
+	}
+
+	for _, status := range []order.OrderStatus{
+		order.StatusConfirmed, order.StatusPreparing, order.StatusReady, order.StatusDelivering, order.StatusCompleted,
+	} {
+		o, err = f.useCase.UpdateStatus(ctx, UpdateStatusCommand{
+			OrderID:   o.ID,
+			Status:    string(status),
+			ActorID:   "admin-1",
+			ActorRole: string(order.ActorAdmin),
+		})
+		if err != nil {
+			t.Fatalf("UpdateStatus(%s): %v", status, err)
+		}
+	}
+	if o.PaymentStatus != order.PaymentCaptured {
+		t.Fatalf("order payment status %s after completion, want captured", o.PaymentStatus)
+	}
+	if got := f.payments.byOrder[o.ID].Status; got != payment.StatusCaptured {
+		t.Fatalf("payment status %s after completion, want captured", got)
+	}
+	if want := f.payments.byOrder[o.ID].ID + ":capture"; len(f.gateway.captures) != 1 || f.gateway.captures[0] != want {
+		t.Fatalf("capture idempotency keys %v, want [%s]", f.gateway.captures, want)
+	}
+}
+
+func TestDeclinedPaymentFailsOrderAndReleasesStockOnce(t *testing.T) {
+	f := newPaymentFlow(t, false)
+
+	o, err := f.placeOrder(t)
+	var failedErr *PaymentFailedError
+	if !errors.As(err, &failedErr) {
+		t.Fatalf("CreateOrder error %v, want *PaymentFailedError", err)
+	}
+	if o == nil || o.Status != order.StatusPaymentFailed || o.PaymentStatus != order.PaymentFailed {
+		t.Fatalf("declined order %+v, want status payment_failed with payment failed", o)
+	}
+	if got := f.payments.byOrder[o.ID].Status; got != payment.StatusFailed {
+		t.Fatalf("payment status %s, want failed", got)
+	}
+	if got := f.products.products["pho"].Stock.Available; got != 5 {
+		t.Fatalf("stock %d after declined payment, want 5", got)
+	}
+
+	// Cleaning up the declined order must not release its stock a second time
+	if _, err := f.useCase.CancelOrder(context.Background(), CancelOrderCommand{
+		OrderID:   o.ID,
+		ActorID:   "customer-1",
+		ActorRole: string(order.ActorCustomer),
+		Reason:    string(order.ReasonCustomerRequest),
+	}); err != nil {
+		t.Fatalf("CancelOrder: %v", err)
+	}
+	if got := f.products.products["pho"].Stock.Available; got != 5 {
+		t.Fatalf("stock %d after cancelling the declined order, want 5", got)
+	}
+}
+
+func TestUpdateStatusRefusesPaymentFailed(t *testing.T) {
+	f := newPaymentFlow(t, true)
+
+	o, err := f.placeOrder(t)
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	_, err = f.useCase.UpdateStatus(context.Background(), UpdateStatusCommand{
+		OrderID:   o.ID,
+		Status:    string(order.StatusPaymentFailed),
+		ActorID:   "admin-1",
+		ActorRole: string(order.ActorAdmin),
+	})
+	if err == nil || !strings.Contains(err.Error(), "validation failed") {
+		t.Fatalf("UpdateStatus(payment_failed) error %v, want a validation error", err)
+	}
+	if got := f.orders.orders[o.ID]; got.Status != order.StatusPending || got.PaymentStatus != order.PaymentAuthorized {
+		t.Fatalf("order moved to %s (payment %s), want it left pending and authorized", got.Status, got.PaymentStatus)
+	}
+	if got := f.products.products["pho"].Stock.Available; got != 3 {
+		t.Fatalf("stock %d, want the reservation of 2 kept", got)
+	}
+}
+
+func TestConcurrentCancelLosesWithoutSecondRefund(t *testing.T) {
+	f := newPaymentFlow(t, true)
+	ctx := context.Background()
+
+	o, err := f.placeOrder(t)
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	// Both requests read the order while it was still pending
+	pending := f.orders.orders[o.ID]
+	f.orders.stale = &pending
+
+	cancel := CancelOrderCommand{
+		OrderID:   o.ID,
+		ActorID:   "customer-1",
+		ActorRole: string(order.ActorCustomer),
+		Reason:    string(order.ReasonCustomerRequest),
+	}
+	if _, err := f.useCase.CancelOrder(ctx, cancel); err != nil {
+		t.Fatalf("first CancelOrder: %v", err)
+	}
+	if _, err := f.useCase.CancelOrder(ctx, cancel); !errors.Is(err, order.ErrStatusChanged) {
+		t.Fatalf("second CancelOrder error %v, want %v", err, order.ErrStatusChanged)
+	}
+
+	// The loser reached the gateway too, but with the same key, so the
+	// gateway refunds once
+	want := f.payments.byOrder[o.ID].ID + ":refund"
+	for _, key := range f.gateway.refunds {
+		if key != want {
+			t.Fatalf("refund idempotency keys %v, want every one to be %s", f.gateway.refunds, want)
+		}
+	}
+	if got := f.products.products["pho"].Stock.Available; got != 5 {
+		t.Fatalf("stock %d after one cancellation, want 5", got)
+	}
+	if got := len(f.orders.history); got != 2 {
+		t.Fatalf("%d timeline entries, want created and one cancellation", got)
+	}
+}
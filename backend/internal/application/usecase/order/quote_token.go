@@ -0,0 +1,83 @@
+package order
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"foodie/backend/internal/domain/order"
+	"foodie/backend/pkg/money"
+)
+
+var (
+	// ErrInvalidQuote is returned when a quote ID was tampered with or does not match the order.
+	ErrInvalidQuote = errors.New("invalid quote")
+	// ErrQuoteExpired is returned when a quote ID is past its expiry.
+	ErrQuoteExpired = errors.New("quote expired")
+)
+
+// quoteClaims is everything CreateOrder needs to honour a quote without re-pricing it.
+type quoteClaims struct {
+	RestaurantID    string               `json:"restaurant_id"`
+	DeliveryAddress string               `json:"delivery_address"`
+	PromoCode       string               `json:"promo_code,omitempty"`
+	Items           []quoteItem          `json:"items"`
+	Pricing         order.PriceBreakdown `json:"pricing"`
+	DistanceKm      float64              `json:"distance_km"`
+	// DeliverySeconds is prep plus travel time; the ETA is measured from order creation.
+	DeliverySeconds int64 `json:"delivery_seconds"`
+	ExpiresAt       int64 `json:"exp"`
+}
+
+type quoteItem struct {
+	ProductID   string               `json:"product_id"`
+	ProductName string               `json:"product_name"`
+	Quantity    int                  `json:"quantity"`
+	Price       money.Money          `json:"price"`
+	Modifiers   []order.ItemModifier `json:"modifiers,omitempty"`
+}
+
+// signQuote encodes claims as "<payload>.<signature>", both base64url, signed with HMAC-SHA256.
+func signQuote(secret []byte, claims quoteClaims) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(quoteSignature(secret, encoded)), nil
+}
+
+// verifyQuote checks the signature and expiry of a quote ID and returns its claims.
+func verifyQuote(secret []byte, token string, now time.Time) (*quoteClaims, error) {
+	encoded, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidQuote
+	}
+	got, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(got, quoteSignature(secret, encoded)) {
+		return nil, ErrInvalidQuote
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidQuote
+	}
+	var claims quoteClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidQuote
+	}
+	if now.Unix() >= claims.ExpiresAt {
+		return nil, ErrQuoteExpired
+	}
+	return &claims, nil
+}
+
+func quoteSignature(secret []byte, encoded string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
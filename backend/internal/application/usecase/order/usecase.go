@@ -2,6 +2,8 @@ package order
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"foodie/backend/internal/domain/order"
 )
@@ -12,11 +14,32 @@ type UseCase interface {
 	// CreateOrder creates a new order.
 	CreateOrder(ctx context.Context, cmd CreateOrderCommand) (*order.Order, error)
 
-	// GetOrder retrieves an order by ID.
-	GetOrder(ctx context.Context, orderID string) (*order.Order, error)
+	// QuoteOrder prices a basket without placing an order.
+	QuoteOrder(ctx context.Context, cmd QuoteCommand) (*Quote, error)
+
+	// GetOrder retrieves an order the caller may see.
+	GetOrder(ctx context.Context, query OrderQuery) (*order.Order, error)
 
 	// ListOrders lists orders with optional filters.
 	ListOrders(ctx context.Context, req ListOrdersRequest) ([]order.Order, int, error)
+
+	// UpdateStatus moves an order to a new status, enforcing the order state machine.
+	UpdateStatus(ctx context.Context, cmd UpdateStatusCommand) (*order.Order, error)
+
+	// CancelOrder cancels an order on behalf of a customer, restaurant or admin,
+	// refunding any authorized or captured payment.
+	CancelOrder(ctx context.Context, cmd CancelOrderCommand) (*order.Order, error)
+
+	// GetOrderTimeline returns every status change of an order the caller may see, oldest first.
+	GetOrderTimeline(ctx context.Context, query OrderQuery) ([]order.StatusChange, error)
+
+	// AcceptOrder confirms a pending order on behalf of its restaurant,
+	// committing to a preparation time and updating the delivery estimate.
+	AcceptOrder(ctx context.Context, cmd AcceptOrderCommand) (*order.Order, error)
+
+	// RejectOrder declines a pending order on behalf of its restaurant,
+	// cancelling it and refunding any payment.
+	RejectOrder(ctx context.Context, cmd RejectOrderCommand) (*order.Order, error)
 }
 
 // CreateOrderCommand represents the command to create an order.
@@ -26,19 +49,127 @@ type CreateOrderCommand struct {
 	Items           []OrderItemCommand
 	PaymentMethod   string
 	DeliveryAddress string
+	PromoCode       string
+	// QuoteID, when set, locks the order to a price returned by QuoteOrder.
+	// The items, restaurant, address and promo code must match the quote.
+	QuoteID string
+}
+
+// QuoteCommand represents the command to price a basket.
+type QuoteCommand struct {
+	RestaurantID    string
+	Items           []OrderItemCommand
+	DeliveryAddress string
+	PromoCode       string
+}
+
+// Quote is a priced basket. ID is a signed token that CreateOrder accepts
+// until ExpiresAt to guarantee the quoted price.
+type Quote struct {
+	ID                  string
+	ExpiresAt           time.Time
+	RestaurantID        string
+	DeliveryAddress     string
+	PromoCode           string
+	Items               []order.OrderItem
+	Pricing             order.PriceBreakdown
+	DistanceKm          float64
+	EstimatedDeliveryAt time.Time
 }
 
 // OrderItemCommand represents an item in the create order command.
 type OrderItemCommand struct {
 	ProductID string
 	Quantity  int
+	Modifiers []ModifierCommand
+}
+
+// ModifierCommand selects one option of a product's modifier group.
+type ModifierCommand struct {
+	GroupID  string
+	OptionID string
+}
+
+// OrderQuery identifies an order and who is reading it. Customers may read
+// their own orders, restaurant accounts the orders of restaurants they own
+// and admins any order; others get order.ErrNotOrderOwner or restaurant.ErrNotOwner.
+type OrderQuery struct {
+	OrderID   string
+	ActorID   string
+	ActorRole string
+}
+
+// UpdateStatusCommand represents the command to change an order's status.
+type UpdateStatusCommand struct {
+	OrderID   string
+	Status    string
+	ActorID   string
+	ActorRole string
+	Note      string
+}
+
+// CancelOrderCommand represents the command to cancel an order.
+type CancelOrderCommand struct {
+	OrderID   string
+	ActorID   string
+	ActorRole string
+	Reason    string
+	Note      string
+}
+
+// AcceptOrderCommand represents a restaurant accepting an incoming order.
+type AcceptOrderCommand struct {
+	RestaurantID    string
+	OrderID         string
+	ActorID         string
+	ActorRole       string
+	PrepTimeMinutes int // Default: the configured preparation time
+}
+
+// RejectOrderCommand represents a restaurant declining an incoming order.
+type RejectOrderCommand struct {
+	RestaurantID string
+	OrderID      string
+	ActorID      string
+	ActorRole    string
+	Reason       string // Default: restaurant_too_busy
+	Note         string
 }
 
 // ListOrdersRequest represents filters for listing orders.
+// Customers only ever see their own orders; restaurants must filter by a
+// restaurant_id they own; admins may list everything. Other roles get
+// order.ErrNotOrderOwner.
 type ListOrdersRequest struct {
-	UserID string
-	Status string
+	ActorID   string
+	ActorRole string
+
+	UserID        string
+	RestaurantID  string
+	Statuses      []string
+	CreatedFrom   *time.Time // Inclusive
+	CreatedTo     *time.Time // Exclusive
+	PaymentMethod string
+	MinTotal      string // Decimal amount, e.g. "10.00"
+	MaxTotal      string
+	Sort          string // Field to sort by, prefixed with "-" for descending (default: "-created_at")
+
 	Page   int // Page number (default: 1)
 	Offset int // Offset (if provided, will be used directly; otherwise calculated from page)
 	Limit  int // Items per page (default: 20)
 }
+
+// PaymentFailedError is returned by CreateOrder when the gateway declines the payment.
+// The order has been stored in payment_failed status.
+type PaymentFailedError struct {
+	OrderID string
+	Err     error
+}
+
+func (e *PaymentFailedError) Error() string {
+	return fmt.Sprintf("payment authorization failed for order %s: %v", e.OrderID, e.Err)
+}
+
+func (e *PaymentFailedError) Unwrap() error {
+	return e.Err
+}
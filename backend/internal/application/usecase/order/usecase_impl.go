@@ -2,81 +2,553 @@ package order
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	"foodie/backend/internal/domain/category"
 	"foodie/backend/internal/domain/order"
+	"foodie/backend/internal/domain/payment"
 	productrepo "foodie/backend/internal/domain/product"
+	"foodie/backend/internal/domain/restaurant"
+	"foodie/backend/internal/infrastructure/database/txn"
+	"foodie/backend/internal/infrastructure/external"
+	"foodie/backend/internal/infrastructure/messaging"
+	"foodie/backend/pkg/money"
 
 	"github.com/google/uuid"
 )
 
+// Dependencies bundles the collaborators required by the order use case.
+type Dependencies struct {
+	OrderRepo   order.Repository
+	ProductRepo productrepo.Repository
+	// CategoryRepo is optional; without it only products' own availability
+	// windows are enforced.
+	CategoryRepo category.Repository
+	PaymentRepo  payment.Repository
+	// RestaurantRepo decides whether a restaurant takes orders, where they are
+	// picked up and the time zone its opening hours and menu windows are read in.
+	RestaurantRepo restaurant.Repository
+	// OwnershipRepo decides which restaurant accounts may act on an order.
+	OwnershipRepo  restaurant.OwnershipRepository
+	PaymentGateway external.PaymentGateway
+	// MapsService quotes the delivery distance and ETA from the restaurant.
+	MapsService external.MapsService
+	// DeliveryFees prices the delivery by distance.
+	DeliveryFees order.FeeSchedule
+	// PrepTime is added to the travel time when estimating delivery.
+	PrepTime time.Duration
+	// Pricing applies service fees, taxes and promo codes on top of item prices.
+	Pricing order.PricingPolicy
+	// Currency is the currency orders are charged in; product prices, the fee
+	// schedule and promotions must use it.
+	Currency string
+	// QuoteSecret signs quote IDs; QuoteTTL is how long a quote can be redeemed.
+	QuoteSecret []byte
+	QuoteTTL    time.Duration
+	TxManager   txn.Manager
+	// Publisher records domain events. It must write within the transaction
+	// carried by ctx (e.g. messaging.OutboxPublisher) so events are never
+	// emitted for rolled-back changes.
+	Publisher messaging.Publisher
+	// ProductCache is optional; when set, the cached products and menus an
+	// order reserves or releases stock from are invalidated.
+	ProductCache ProductCache
+}
+
+// ProductCache drops cached product and menu data. The product use case
+// implements it.
+type ProductCache interface {
+	InvalidateProductCache(ctx context.Context, productID string) error
+	InvalidateMenuCache(ctx context.Context, restaurantID string) error
+}
+
 // useCaseImpl implements the UseCase interface.
 type useCaseImpl struct {
-	orderRepo   order.Repository
-	productRepo productrepo.Repository
+	orderRepo      order.Repository
+	productRepo    productrepo.Repository
+	categoryRepo   category.Repository
+	paymentRepo    payment.Repository
+	restaurantRepo restaurant.Repository
+	ownershipRepo  restaurant.OwnershipRepository
+	paymentGateway external.PaymentGateway
+	maps           external.MapsService
+	deliveryFees   order.FeeSchedule
+	prepTime       time.Duration
+	pricing        order.PricingPolicy
+	currency       string
+	quoteSecret    []byte
+	quoteTTL       time.Duration
+	txManager      txn.Manager
+	publisher      messaging.Publisher
+	productCache   ProductCache // Optional, can be nil
 }
 
 // NewUseCase creates a new order use case.
-func NewUseCase(orderRepo order.Repository, productRepo productrepo.Repository) UseCase {
+func NewUseCase(deps Dependencies) UseCase {
 	return &useCaseImpl{
-		orderRepo:   orderRepo,
-		productRepo: productRepo,
+		orderRepo:      deps.OrderRepo,
+		productRepo:    deps.ProductRepo,
+		categoryRepo:   deps.CategoryRepo,
+		paymentRepo:    deps.PaymentRepo,
+		restaurantRepo: deps.RestaurantRepo,
+		ownershipRepo:  deps.OwnershipRepo,
+		paymentGateway: deps.PaymentGateway,
+		maps:           deps.MapsService,
+		deliveryFees:   deps.DeliveryFees,
+		prepTime:       deps.PrepTime,
+		pricing:        deps.Pricing,
+		currency:       deps.Currency,
+		quoteSecret:    deps.QuoteSecret,
+		quoteTTL:       deps.QuoteTTL,
+		txManager:      deps.TxManager,
+		publisher:      deps.Publisher,
+		productCache:   deps.ProductCache,
 	}
 }
 
-// CreateOrder creates a new order.
+// CreateOrder creates a new order and authorizes its payment.
+// If the gateway declines, the order is kept in payment_failed and
+// *PaymentFailedError is returned together with it.
 func (uc *useCaseImpl) CreateOrder(ctx context.Context, cmd CreateOrderCommand) (*order.Order, error) {
 	// 1. Validate command
 	if err := uc.validateCreateCommand(cmd); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
-	// 2. Fetch products and calculate total
-	items := make([]order.OrderItem, 0, len(cmd.Items))
-	var total float64
+	// 2. The restaurant must exist and be taking orders
+	now := time.Now()
+	rest, err := uc.openRestaurant(ctx, cmd.RestaurantID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Price the order, or honour a previously issued quote
+	var priced *pricedOrder
+	if cmd.QuoteID != "" {
+		priced, err = uc.redeemQuote(cmd, now)
+		if err == nil {
+			err = uc.checkQuotedAvailability(ctx, rest, priced.Items, now)
+		}
+	} else {
+		priced, err = uc.priceOrder(ctx, rest, cmd.DeliveryAddress, cmd.PromoCode, cmd.Items)
+	}
+	if err != nil {
+		return nil, err
+	}
+	estimatedDeliveryAt := now.Add(priced.DeliveryTime)
+	total := priced.Pricing.Total
+
+	// 4. Create order entity
+	orderEntity := &order.Order{
+		ID:                  uuid.New().String(),
+		UserID:              cmd.UserID,
+		RestaurantID:        cmd.RestaurantID,
+		Status:              order.StatusPending,
+		Items:               priced.Items,
+		Subtotal:            priced.Pricing.Subtotal,
+		Discount:            priced.Pricing.Discount,
+		ServiceFee:          priced.Pricing.ServiceFee,
+		Tax:                 priced.Pricing.Tax,
+		Total:               total,
+		PromoCode:           priced.PromoCode,
+		PaymentMethod:       cmd.PaymentMethod,
+		PaymentStatus:       order.PaymentUnpaid,
+		DeliveryAddress:     cmd.DeliveryAddress,
+		DeliveryFee:         priced.Pricing.DeliveryFee,
+		DistanceKm:          priced.DistanceKm,
+		EstimatedDeliveryAt: &estimatedDeliveryAt,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+	}
+
+	paymentEntity := &payment.Payment{
+		ID:        uuid.New().String(),
+		OrderID:   orderEntity.ID,
+		Method:    cmd.PaymentMethod,
+		Amount:    total,
+		Status:    payment.StatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	orderEntity.PaymentID = paymentEntity.ID
+
+	// 5. Reserve stock, save via repository, start the timeline and emit
+	// order.created in the same transaction: a sold-out item leaves no trace
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.reserveStock(ctx, orderEntity); err != nil {
+			return err
+		}
+		if err := uc.orderRepo.Save(ctx, orderEntity); err != nil {
+			return fmt.Errorf("failed to save order: %w", err)
+		}
+		if err := uc.paymentRepo.Save(ctx, paymentEntity); err != nil {
+			return fmt.Errorf("failed to save payment: %w", err)
+		}
+		if err := uc.recordStatusChange(ctx, orderEntity, "", cmd.UserID, order.ActorCustomer, ""); err != nil {
+			return err
+		}
+		return uc.emit(ctx, order.EventCreated, orderEntity, map[string]interface{}{
+			"items_count":           len(orderEntity.Items),
+			"payment_method":        orderEntity.PaymentMethod,
+			"delivery_fee":          orderEntity.DeliveryFee,
+			"estimated_delivery_at": estimatedDeliveryAt.Unix(),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.invalidateStock(ctx, orderEntity)
+
+	// 6. Authorize outside the transaction: the gateway call must not hold a
+	// database transaction open, and a declined order must still exist.
+	if paymentEntity.RequiresGateway() {
+		if err := uc.authorizePayment(ctx, orderEntity, paymentEntity); err != nil {
+			return orderEntity, err
+		}
+	}
+
+	return orderEntity, nil
+}
+
+// QuoteOrder prices a basket exactly as CreateOrder would and signs the result
+// so it can be redeemed by CreateOrder before it expires.
+func (uc *useCaseImpl) QuoteOrder(ctx context.Context, cmd QuoteCommand) (*Quote, error) {
+	if err := validateBasket(cmd.RestaurantID, cmd.DeliveryAddress, cmd.Items); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	now := time.Now()
+	rest, err := uc.openRestaurant(ctx, cmd.RestaurantID, now)
+	if err != nil {
+		return nil, err
+	}
+	priced, err := uc.priceOrder(ctx, rest, cmd.DeliveryAddress, cmd.PromoCode, cmd.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := now.Add(uc.quoteTTL)
+	claims := quoteClaims{
+		RestaurantID:    cmd.RestaurantID,
+		DeliveryAddress: cmd.DeliveryAddress,
+		PromoCode:       priced.PromoCode,
+		Pricing:         priced.Pricing,
+		DistanceKm:      priced.DistanceKm,
+		DeliverySeconds: int64(priced.DeliveryTime / time.Second),
+		ExpiresAt:       expiresAt.Unix(),
+	}
+	for _, item := range priced.Items {
+		claims.Items = append(claims.Items, quoteItem{
+			ProductID:   item.ProductID,
+			ProductName: item.ProductName,
+			Quantity:    item.Quantity,
+			Price:       item.Price,
+			Modifiers:   item.Modifiers,
+		})
+	}
+
+	id, err := signQuote(uc.quoteSecret, claims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign quote: %w", err)
+	}
+
+	return &Quote{
+		ID:                  id,
+		ExpiresAt:           expiresAt,
+		RestaurantID:        cmd.RestaurantID,
+		DeliveryAddress:     cmd.DeliveryAddress,
+		PromoCode:           priced.PromoCode,
+		Items:               priced.Items,
+		Pricing:             priced.Pricing,
+		DistanceKm:          priced.DistanceKm,
+		EstimatedDeliveryAt: now.Add(priced.DeliveryTime),
+	}, nil
+}
+
+// pricedOrder is a basket priced for delivery, shared by quotes and orders.
+type pricedOrder struct {
+	Items      []order.OrderItem
+	Pricing    order.PriceBreakdown
+	PromoCode  string
+	DistanceKm float64
+	// DeliveryTime is the kitchen's preparation time plus the travel time.
+	DeliveryTime time.Duration
+}
+
+// priceOrder looks up item prices, quotes the delivery and applies fees, taxes and the promo code.
+// Items from another restaurant's menu or outside their availability windows are rejected.
+func (uc *useCaseImpl) priceOrder(ctx context.Context, rest *restaurant.Restaurant, address, promoCode string, itemCmds []OrderItemCommand) (*pricedOrder, error) {
+	var promo *order.Promotion
+	if promoCode != "" {
+		p, ok := uc.pricing.Promotion(promoCode)
+		if !ok {
+			return nil, fmt.Errorf("validation failed: unknown promo code %q", promoCode)
+		}
+		promo = &p
+	}
+
+	// Fetch products and calculate subtotal
+	items := make([]order.OrderItem, 0, len(itemCmds))
+	subtotal := money.Zero(uc.currency)
+	now := time.Now()
 
-	for _, itemCmd := range cmd.Items {
+	for i, itemCmd := range itemCmds {
 		// Fetch product to get price and name
 		product, err := uc.productRepo.FindByID(ctx, itemCmd.ProductID)
 		if err != nil {
 			return nil, fmt.Errorf("product not found: %s: %w", itemCmd.ProductID, err)
 		}
 
-		itemTotal := product.Price * float64(itemCmd.Quantity)
-		total += itemTotal
+		if product.RestaurantID != rest.ID {
+			return nil, fmt.Errorf("validation failed: items[%d]: product %s is not on %s's menu", i, product.ID, rest.Name)
+		}
+		if product.Price.Currency != uc.currency {
+			return nil, fmt.Errorf("validation failed: product %s is priced in %s, orders are charged in %s",
+				product.ID, product.Price.Currency, uc.currency)
+		}
+		reason, err := uc.unavailableReason(ctx, product, now, rest.Zone())
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			return nil, fmt.Errorf("validation failed: items[%d]: %s", i, reason)
+		}
+		// Fail fast; the authoritative check is the reservation in CreateOrder
+		if product.Stock.SoldOut() {
+			return nil, &productrepo.OutOfStockError{ProductID: product.ID, Requested: itemCmd.Quantity}
+		}
 
-		items = append(items, order.OrderItem{
+		modifiers, err := selectModifiers(product, itemCmd.Modifiers)
+		if err != nil {
+			return nil, fmt.Errorf("validation failed: items[%d]: %w", i, err)
+		}
+
+		item := order.OrderItem{
 			ProductID:   product.ID,
 			ProductName: product.Name,
 			Quantity:    itemCmd.Quantity,
 			Price:       product.Price,
+			Modifiers:   modifiers,
+		}
+		subtotal = subtotal.Add(item.LineTotal())
+		items = append(items, item)
+	}
+
+	delivery, err := uc.quoteDelivery(ctx, rest, address)
+	if err != nil {
+		return nil, err
+	}
+
+	priced := &pricedOrder{
+		Items:        items,
+		Pricing:      uc.pricing.Price(subtotal, delivery.Fee, promo),
+		DistanceKm:   delivery.DistanceKm,
+		DeliveryTime: delivery.Duration,
+	}
+	if promo != nil {
+		priced.PromoCode = promo.Code
+	}
+	return priced, nil
+}
+
+// selectModifiers validates an item's modifier choices against the product
+// and copies the chosen options, with their price deltas, onto the order item.
+func selectModifiers(p *productrepo.Product, cmds []ModifierCommand) ([]order.ItemModifier, error) {
+	selections := make([]productrepo.ModifierSelection, 0, len(cmds))
+	for _, cmd := range cmds {
+		selections = append(selections, productrepo.ModifierSelection{GroupID: cmd.GroupID, OptionID: cmd.OptionID})
+	}
+	selected, err := p.SelectModifiers(selections)
+	if err != nil {
+		return nil, err
+	}
+	if len(selected) == 0 {
+		return nil, nil
+	}
+
+	modifiers := make([]order.ItemModifier, 0, len(selected))
+	for _, s := range selected {
+		modifiers = append(modifiers, order.ItemModifier{
+			GroupID:    s.GroupID,
+			GroupName:  s.GroupName,
+			OptionID:   s.OptionID,
+			OptionName: s.OptionName,
+			PriceDelta: s.PriceDelta,
 		})
 	}
+	return modifiers, nil
+}
 
-	// 3. Create order entity
-	now := time.Now()
-	orderEntity := &order.Order{
-		ID:              uuid.New().String(),
-		UserID:          cmd.UserID,
-		RestaurantID:    cmd.RestaurantID,
-		Status:          order.StatusPending,
-		Items:           items,
-		Total:           total,
-		PaymentMethod:   cmd.PaymentMethod,
-		DeliveryAddress: cmd.DeliveryAddress,
-		CreatedAt:       now,
-		UpdatedAt:       now,
+// redeemQuote verifies a quote ID and checks it was issued for the basket in cmd.
+func (uc *useCaseImpl) redeemQuote(cmd CreateOrderCommand, now time.Time) (*pricedOrder, error) {
+	claims, err := verifyQuote(uc.quoteSecret, cmd.QuoteID, now)
+	if err != nil {
+		return nil, err
 	}
 
-	// 4. Save via repository
-	if err := uc.orderRepo.Save(ctx, orderEntity); err != nil {
-		return nil, fmt.Errorf("failed to save order: %w", err)
+	if claims.RestaurantID != cmd.RestaurantID ||
+		claims.DeliveryAddress != cmd.DeliveryAddress ||
+		!strings.EqualFold(claims.PromoCode, cmd.PromoCode) ||
+		!sameItems(claims.Items, cmd.Items) {
+		return nil, fmt.Errorf("%w: order does not match the quoted basket", ErrInvalidQuote)
 	}
 
-	// 5. TODO: Emit domain events (order created)
+	items := make([]order.OrderItem, 0, len(claims.Items))
+	for _, item := range claims.Items {
+		items = append(items, order.OrderItem{
+			ProductID:   item.ProductID,
+			ProductName: item.ProductName,
+			Quantity:    item.Quantity,
+			Price:       item.Price,
+			Modifiers:   item.Modifiers,
+		})
+	}
 
-	return orderEntity, nil
+	return &pricedOrder{
+		Items:        items,
+		Pricing:      claims.Pricing,
+		PromoCode:    claims.PromoCode,
+		DistanceKm:   claims.DistanceKm,
+		DeliveryTime: time.Duration(claims.DeliverySeconds) * time.Second,
+	}, nil
+}
+
+// sameItems reports whether quoted and requested contain the same products,
+// with the same modifiers, in the same quantities.
+func sameItems(quoted []quoteItem, requested []OrderItemCommand) bool {
+	quantities := make(map[string]int)
+	for _, item := range quoted {
+		options := make([]string, 0, len(item.Modifiers))
+		for _, m := range item.Modifiers {
+			options = append(options, m.GroupID+"/"+m.OptionID)
+		}
+		quantities[itemKey(item.ProductID, options)] += item.Quantity
+	}
+	for _, item := range requested {
+		options := make([]string, 0, len(item.Modifiers))
+		for _, m := range item.Modifiers {
+			options = append(options, m.GroupID+"/"+m.OptionID)
+		}
+		quantities[itemKey(item.ProductID, options)] -= item.Quantity
+	}
+	for _, q := range quantities {
+		if q != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// itemKey identifies a product with a particular set of modifier options.
+func itemKey(productID string, options []string) string {
+	sort.Strings(options)
+	return productID + "|" + strings.Join(options, ",")
+}
+
+// deliveryQuote is the priced delivery leg of an order.
+type deliveryQuote struct {
+	Fee        money.Money
+	DistanceKm float64
+	// Duration is the kitchen's preparation time plus the travel time.
+	Duration time.Duration
+}
+
+// quoteDelivery geocodes the delivery address, measures the distance from the
+// restaurant and prices it with the fee schedule.
+func (uc *useCaseImpl) quoteDelivery(ctx context.Context, rest *restaurant.Restaurant, address string) (*deliveryQuote, error) {
+	origin := &external.Location{Latitude: rest.Location.Latitude, Longitude: rest.Location.Longitude}
+	destination, err := uc.maps.Geocode(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: delivery_address could not be located: %w", err)
+	}
+
+	distanceKm, err := uc.maps.CalculateDistance(ctx, *origin, *destination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate delivery distance: %w", err)
+	}
+	fee, err := uc.deliveryFees.FeeFor(distanceKm)
+	if err != nil {
+		return nil, err
+	}
+
+	travelMinutes, err := uc.maps.EstimateDeliveryTime(ctx, *origin, *destination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate delivery time: %w", err)
+	}
+
+	return &deliveryQuote{
+		Fee:        fee,
+		DistanceKm: distanceKm,
+		Duration:   uc.prepTime + time.Duration(travelMinutes)*time.Minute,
+	}, nil
+}
+
+// authorizePayment reserves the order total with the payment gateway.
+// A decline moves the order to payment_failed and returns *PaymentFailedError.
+func (uc *useCaseImpl) authorizePayment(ctx context.Context, o *order.Order, p *payment.Payment) error {
+	resp, authErr := uc.paymentGateway.AuthorizePayment(ctx, external.PaymentRequest{
+		OrderID:        o.ID,
+		Amount:         p.Amount,
+		PaymentMethod:  p.Method,
+		IdempotencyKey: p.IdempotencyKey("authorize"),
+	})
+	if authErr == nil && resp.Status == "failed" {
+		authErr = fmt.Errorf("payment declined")
+	}
+
+	now := time.Now()
+	previous := o.Status
+	var err error
+	if authErr != nil {
+		err = p.Fail(authErr.Error(), now)
+		if err == nil {
+			err = o.TransitionTo(order.StatusPaymentFailed, now)
+		}
+		o.PaymentStatus = order.PaymentFailed
+	} else {
+		err = p.Authorize(resp.PaymentID, resp.TransactionID, now)
+		o.PaymentStatus = order.PaymentAuthorized
+		o.UpdatedAt = now
+	}
+	if err != nil {
+		return err
+	}
+
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.paymentRepo.Update(ctx, p); err != nil {
+			return fmt.Errorf("failed to update payment: %w", err)
+		}
+		if err := uc.orderRepo.Update(ctx, o, previous); err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+		if authErr == nil {
+			return nil
+		}
+		// A declined order will not be fulfilled; free its stock for other customers
+		if err := uc.releaseStock(ctx, o); err != nil {
+			return err
+		}
+		if err := uc.recordStatusChange(ctx, o, previous, "", order.ActorSystem, authErr.Error()); err != nil {
+			return err
+		}
+		return uc.emit(ctx, order.StatusEventType(order.StatusPaymentFailed), o, map[string]interface{}{
+			"payment_id":     p.ID,
+			"failure_reason": p.FailureReason,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	if authErr != nil {
+		uc.invalidateStock(ctx, o)
+		return &PaymentFailedError{OrderID: o.ID, Err: authErr}
+	}
+	return nil
 }
 
 // validateCreateCommand validates the create order command.
@@ -84,13 +556,24 @@ func (uc *useCaseImpl) validateCreateCommand(cmd CreateOrderCommand) error {
 	if cmd.UserID == "" {
 		return fmt.Errorf("user_id is required")
 	}
-	if cmd.RestaurantID == "" {
+	if err := validateBasket(cmd.RestaurantID, cmd.DeliveryAddress, cmd.Items); err != nil {
+		return err
+	}
+	if cmd.PaymentMethod == "" {
+		return fmt.Errorf("payment_method is required")
+	}
+	return nil
+}
+
+// validateBasket validates the fields shared by orders and quotes.
+func validateBasket(restaurantID, deliveryAddress string, items []OrderItemCommand) error {
+	if restaurantID == "" {
 		return fmt.Errorf("restaurant_id is required")
 	}
-	if len(cmd.Items) == 0 {
+	if len(items) == 0 {
 		return fmt.Errorf("order must have at least one item")
 	}
-	for i, item := range cmd.Items {
+	for i, item := range items {
 		if item.ProductID == "" {
 			return fmt.Errorf("items[%d].product_id is required", i)
 		}
@@ -98,30 +581,480 @@ func (uc *useCaseImpl) validateCreateCommand(cmd CreateOrderCommand) error {
 			return fmt.Errorf("items[%d].quantity must be greater than 0", i)
 		}
 	}
-	if cmd.PaymentMethod == "" {
-		return fmt.Errorf("payment_method is required")
-	}
-	if cmd.DeliveryAddress == "" {
+	if deliveryAddress == "" {
 		return fmt.Errorf("delivery_address is required")
 	}
 	return nil
 }
 
 // GetOrder retrieves an order by ID.
-func (uc *useCaseImpl) GetOrder(ctx context.Context, orderID string) (*order.Order, error) {
-	if orderID == "" {
+func (uc *useCaseImpl) GetOrder(ctx context.Context, query OrderQuery) (*order.Order, error) {
+	if query.OrderID == "" {
 		return nil, fmt.Errorf("order_id is required")
 	}
 
-	o, err := uc.orderRepo.FindByID(ctx, orderID)
+	o, err := uc.orderRepo.FindByID(ctx, query.OrderID)
 	if err != nil {
 		return nil, fmt.Errorf("order not found: %w", err)
 	}
+	if err := uc.authorizeOrderAccess(ctx, o, query.ActorID, order.ActorRole(query.ActorRole)); err != nil {
+		return nil, err
+	}
 
 	return o, nil
 }
 
-// ListOrders lists orders with optional filters.
+// authorizeOrderAccess checks that the actor may read o: its customer, the
+// restaurant fulfilling it, or an admin.
+func (uc *useCaseImpl) authorizeOrderAccess(ctx context.Context, o *order.Order, actorID string, role order.ActorRole) error {
+	switch role {
+	case order.ActorAdmin:
+		return nil
+	case order.ActorRestaurant:
+		return uc.authorizeRestaurant(ctx, o.RestaurantID, actorID, role)
+	case order.ActorCustomer:
+		if o.UserID == actorID {
+			return nil
+		}
+	}
+	return order.ErrNotOrderOwner
+}
+
+// UpdateStatus moves an order to a new status on behalf of its restaurant or an admin.
+// Illegal moves (e.g. completed -> preparing) return *order.InvalidTransitionError;
+// other callers get order.ErrNotOrderOwner.
+func (uc *useCaseImpl) UpdateStatus(ctx context.Context, cmd UpdateStatusCommand) (*order.Order, error) {
+	if cmd.OrderID == "" {
+		return nil, fmt.Errorf("validation failed: order_id is required")
+	}
+	next := order.OrderStatus(cmd.Status)
+	if !next.IsValid() {
+		return nil, fmt.Errorf("validation failed: unknown status %q", cmd.Status)
+	}
+	if next == order.StatusCancelled {
+		return nil, fmt.Errorf("validation failed: use the cancel endpoint to cancel an order")
+	}
+	// Only a declined authorization may fail the payment: it also voids the
+	// payment and releases the order's stock
+	if next == order.StatusPaymentFailed {
+		return nil, fmt.Errorf("validation failed: payment_failed is set by the payment gateway")
+	}
+
+	o, err := uc.orderRepo.FindByID(ctx, cmd.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("order not found: %w", err)
+	}
+	// Only the restaurant fulfilling the order and admins move it along;
+	// customers cancel through CancelOrder
+	switch order.ActorRole(cmd.ActorRole) {
+	case order.ActorAdmin:
+	case order.ActorRestaurant:
+		if err := uc.authorizeRestaurant(ctx, o.RestaurantID, cmd.ActorID, order.ActorRestaurant); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, order.ErrNotOrderOwner
+	}
+
+	previous := o.Status
+	now := time.Now()
+	if err := o.TransitionTo(next, now); err != nil {
+		return nil, err
+	}
+
+	// Capture the payment once the order is delivered. As with refunds, the
+	// gateway is called before persisting so a failed capture can be retried;
+	// the idempotency key keeps a retry after a failed write from capturing
+	// twice, and the conditional update makes a concurrent request fail.
+	var p *payment.Payment
+	if next == order.StatusCompleted {
+		p, err = uc.capturePayment(ctx, o, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.orderRepo.Update(ctx, o, previous); err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+		if p != nil {
+			if err := uc.paymentRepo.Update(ctx, p); err != nil {
+				return fmt.Errorf("failed to update payment: %w", err)
+			}
+		}
+		if err := uc.recordStatusChange(ctx, o, previous, cmd.ActorID, order.ActorRole(cmd.ActorRole), cmd.Note); err != nil {
+			return err
+		}
+		return uc.emit(ctx, order.StatusEventType(next), o, map[string]interface{}{
+			"previous_status": string(previous),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// CancelOrder cancels an order and refunds any payment already taken.
+// The refund is issued before the cancellation is persisted so that a failed
+// refund leaves the order untouched and the caller can retry. Refunds carry an
+// idempotency key, and of two concurrent cancellations only one is written;
+// the other returns order.ErrStatusChanged.
+func (uc *useCaseImpl) CancelOrder(ctx context.Context, cmd CancelOrderCommand) (*order.Order, error) {
+	if cmd.OrderID == "" {
+		return nil, fmt.Errorf("validation failed: order_id is required")
+	}
+	reason := order.CancellationReason(cmd.Reason)
+	if !reason.IsValid() {
+		return nil, fmt.Errorf("validation failed: unknown cancellation reason %q", cmd.Reason)
+	}
+	role := order.ActorRole(cmd.ActorRole)
+
+	o, err := uc.orderRepo.FindByID(ctx, cmd.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("order not found: %w", err)
+	}
+
+	if role == order.ActorCustomer && o.UserID != cmd.ActorID {
+		return nil, order.ErrNotOrderOwner
+	}
+	if role == order.ActorRestaurant {
+		if err := uc.authorizeRestaurant(ctx, o.RestaurantID, cmd.ActorID, role); err != nil {
+			return nil, err
+		}
+	}
+
+	previous := o.Status
+	err = o.Cancel(order.Cancellation{
+		By:     cmd.ActorID,
+		Role:   role,
+		Reason: reason,
+		Note:   cmd.Note,
+		At:     time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := uc.refundPayment(ctx, o, o.Cancellation.At)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.orderRepo.Update(ctx, o, previous); err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+		if p != nil {
+			if err := uc.paymentRepo.Update(ctx, p); err != nil {
+				return fmt.Errorf("failed to update payment: %w", err)
+			}
+		}
+		// Stock of declined orders was already released when the payment failed
+		if previous != order.StatusPaymentFailed {
+			if err := uc.releaseStock(ctx, o); err != nil {
+				return err
+			}
+		}
+		note := string(reason)
+		if cmd.Note != "" {
+			note += ": " + cmd.Note
+		}
+		if err := uc.recordStatusChange(ctx, o, previous, cmd.ActorID, role, note); err != nil {
+			return err
+		}
+		return uc.emit(ctx, order.EventCancelled, o, map[string]interface{}{
+			"cancelled_by":   cmd.ActorID,
+			"role":           string(role),
+			"reason":         string(reason),
+			"payment_status": string(o.PaymentStatus),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if previous != order.StatusPaymentFailed {
+		uc.invalidateStock(ctx, o)
+	}
+
+	return o, nil
+}
+
+// capturePayment collects the funds for a completed order and returns the
+// updated payment to persist, or nil if the order has no tracked payment.
+func (uc *useCaseImpl) capturePayment(ctx context.Context, o *order.Order, now time.Time) (*payment.Payment, error) {
+	p, err := uc.paymentRepo.FindByOrderID(ctx, o.ID)
+	if errors.Is(err, payment.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch payment: %w", err)
+	}
+
+	if p.RequiresGateway() {
+		if err := uc.paymentGateway.CapturePayment(ctx, p.GatewayPaymentID, p.Amount, p.IdempotencyKey("capture")); err != nil {
+			return nil, fmt.Errorf("payment capture failed: %w", err)
+		}
+	}
+	if err := p.Capture(now); err != nil {
+		return nil, fmt.Errorf("payment capture failed: %w", err)
+	}
+	o.PaymentStatus = order.PaymentCaptured
+	return p, nil
+}
+
+// refundPayment returns reserved or collected funds for a cancelled order and
+// returns the updated payment to persist, or nil if there is nothing to refund.
+func (uc *useCaseImpl) refundPayment(ctx context.Context, o *order.Order, now time.Time) (*payment.Payment, error) {
+	p, err := uc.paymentRepo.FindByOrderID(ctx, o.ID)
+	if errors.Is(err, payment.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch payment: %w", err)
+	}
+	if !p.IsRefundable() {
+		return nil, nil
+	}
+
+	if p.RequiresGateway() {
+		if err := uc.paymentGateway.RefundPayment(ctx, p.GatewayPaymentID, p.Amount, p.IdempotencyKey("refund")); err != nil {
+			return nil, fmt.Errorf("refund failed: %w", err)
+		}
+	}
+	if err := p.Refund(now); err != nil {
+		return nil, fmt.Errorf("refund failed: %w", err)
+	}
+	o.PaymentStatus = order.PaymentRefunded
+	return p, nil
+}
+
+// stockLine is the total quantity of one product across an order's items.
+type stockLine struct {
+	productID string
+	quantity  int
+}
+
+// stockLines sums item quantities per product, ordered by product ID so
+// concurrent orders lock product rows in the same order.
+func stockLines(o *order.Order) []stockLine {
+	quantities := make(map[string]int, len(o.Items))
+	for _, item := range o.Items {
+		quantities[item.ProductID] += item.Quantity
+	}
+	lines := make([]stockLine, 0, len(quantities))
+	for productID, quantity := range quantities {
+		lines = append(lines, stockLine{productID: productID, quantity: quantity})
+	}
+	sort.Slice(lines, func(i, j int) bool { return lines[i].productID < lines[j].productID })
+	return lines
+}
+
+// reserveStock takes stock for every product in the order. It must run inside
+// the order's transaction so a later shortage rolls back earlier reservations.
+func (uc *useCaseImpl) reserveStock(ctx context.Context, o *order.Order) error {
+	for _, line := range stockLines(o) {
+		if err := uc.productRepo.ReserveStock(ctx, line.productID, line.quantity); err != nil {
+			return fmt.Errorf("failed to reserve stock: %w", err)
+		}
+	}
+	return nil
+}
+
+// releaseStock returns the order's reserved stock.
+func (uc *useCaseImpl) releaseStock(ctx context.Context, o *order.Order) error {
+	for _, line := range stockLines(o) {
+		if err := uc.productRepo.ReleaseStock(ctx, line.productID, line.quantity); err != nil {
+			return fmt.Errorf("failed to release stock: %w", err)
+		}
+	}
+	return nil
+}
+
+// invalidateStock drops the cached products and menu an order reserved or
+// released stock from. Call it after the transaction commits, or a concurrent
+// read could cache the old stock again. Failures are left to the cache TTL.
+func (uc *useCaseImpl) invalidateStock(ctx context.Context, o *order.Order) {
+	if uc.productCache == nil {
+		return
+	}
+	for _, line := range stockLines(o) {
+		_ = uc.productCache.InvalidateProductCache(ctx, line.productID)
+	}
+	_ = uc.productCache.InvalidateMenuCache(ctx, o.RestaurantID)
+}
+
+// AcceptOrder confirms a pending order and commits to a preparation time.
+// The delivery estimate is moved to now + preparation time + the travel time
+// quoted when the order was placed.
+func (uc *useCaseImpl) AcceptOrder(ctx context.Context, cmd AcceptOrderCommand) (*order.Order, error) {
+	if cmd.PrepTimeMinutes < 0 || cmd.PrepTimeMinutes > maxPrepTimeMinutes {
+		return nil, fmt.Errorf("validation failed: prep_time_minutes must be between 0 and %d (0 uses the default)", maxPrepTimeMinutes)
+	}
+	prepTime := time.Duration(cmd.PrepTimeMinutes) * time.Minute
+	if prepTime == 0 {
+		prepTime = uc.prepTime
+	}
+
+	o, err := uc.findRestaurantOrder(ctx, cmd.RestaurantID, cmd.OrderID, cmd.ActorID, order.ActorRole(cmd.ActorRole))
+	if err != nil {
+		return nil, err
+	}
+	if o.Status != order.StatusPending {
+		return nil, &order.InvalidTransitionError{From: o.Status, To: order.StatusConfirmed}
+	}
+
+	// Travel time is whatever the original estimate allowed beyond the default preparation time
+	var travel time.Duration
+	if o.EstimatedDeliveryAt != nil {
+		travel = max(o.EstimatedDeliveryAt.Sub(o.CreatedAt)-uc.prepTime, 0)
+	}
+
+	previous := o.Status
+	now := time.Now()
+	if err := o.TransitionTo(order.StatusConfirmed, now); err != nil {
+		return nil, err
+	}
+	o.PrepTimeMinutes = int(prepTime / time.Minute)
+	estimatedDeliveryAt := now.Add(prepTime + travel)
+	o.EstimatedDeliveryAt = &estimatedDeliveryAt
+
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.orderRepo.Update(ctx, o, previous); err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+		note := fmt.Sprintf("accepted, ready in %d min", o.PrepTimeMinutes)
+		if err := uc.recordStatusChange(ctx, o, previous, cmd.ActorID, order.ActorRole(cmd.ActorRole), note); err != nil {
+			return err
+		}
+		return uc.emit(ctx, order.StatusEventType(order.StatusConfirmed), o, map[string]interface{}{
+			"previous_status":       string(previous),
+			"prep_time_minutes":     o.PrepTimeMinutes,
+			"estimated_delivery_at": estimatedDeliveryAt.Unix(),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// maxPrepTimeMinutes caps the preparation time a restaurant can commit to.
+const maxPrepTimeMinutes = 240
+
+// RejectOrder declines a pending order. It is a restaurant cancellation, so
+// any authorized payment is refunded.
+func (uc *useCaseImpl) RejectOrder(ctx context.Context, cmd RejectOrderCommand) (*order.Order, error) {
+	o, err := uc.findRestaurantOrder(ctx, cmd.RestaurantID, cmd.OrderID, cmd.ActorID, order.ActorRole(cmd.ActorRole))
+	if err != nil {
+		return nil, err
+	}
+	if o.Status != order.StatusPending {
+		return nil, &order.InvalidTransitionError{From: o.Status, To: order.StatusCancelled}
+	}
+
+	reason := cmd.Reason
+	if reason == "" {
+		reason = string(order.ReasonRestaurantTooBusy)
+	}
+
+	return uc.CancelOrder(ctx, CancelOrderCommand{
+		OrderID:   cmd.OrderID,
+		ActorID:   cmd.ActorID,
+		ActorRole: cmd.ActorRole,
+		Reason:    reason,
+		Note:      cmd.Note,
+	})
+}
+
+// findRestaurantOrder loads an order for a restaurant-scoped action, checking
+// that the caller may act for the restaurant and that the order belongs to it.
+func (uc *useCaseImpl) findRestaurantOrder(ctx context.Context, restaurantID, orderID, actorID string, role order.ActorRole) (*order.Order, error) {
+	if restaurantID == "" || orderID == "" {
+		return nil, fmt.Errorf("validation failed: restaurant_id and order_id are required")
+	}
+	if err := uc.authorizeRestaurant(ctx, restaurantID, actorID, role); err != nil {
+		return nil, err
+	}
+
+	o, err := uc.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("order not found: %w", err)
+	}
+	// Do not reveal other restaurants' orders
+	if o.RestaurantID != restaurantID {
+		return nil, fmt.Errorf("order not found: %s", orderID)
+	}
+	return o, nil
+}
+
+// authorizeRestaurant checks that the actor may manage restaurantID.
+func (uc *useCaseImpl) authorizeRestaurant(ctx context.Context, restaurantID, actorID string, role order.ActorRole) error {
+	return restaurant.Authorize(ctx, uc.ownershipRepo, restaurantID, actorID, string(role))
+}
+
+// GetOrderTimeline returns the status history of an order, oldest first.
+func (uc *useCaseImpl) GetOrderTimeline(ctx context.Context, query OrderQuery) ([]order.StatusChange, error) {
+	if _, err := uc.GetOrder(ctx, query); err != nil {
+		return nil, err
+	}
+
+	history, err := uc.orderRepo.FindStatusHistory(ctx, query.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch order timeline: %w", err)
+	}
+	return history, nil
+}
+
+// recordStatusChange appends o's move from previous to its current status to the timeline.
+func (uc *useCaseImpl) recordStatusChange(ctx context.Context, o *order.Order, previous order.OrderStatus, actorID string, role order.ActorRole, note string) error {
+	err := uc.orderRepo.SaveStatusChange(ctx, &order.StatusChange{
+		OrderID:    o.ID,
+		FromStatus: previous,
+		ToStatus:   o.Status,
+		ActorID:    actorID,
+		ActorRole:  role,
+		Note:       note,
+		CreatedAt:  o.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record status change: %w", err)
+	}
+	return nil
+}
+
+// emit records a domain event for o. Common order fields are always included
+// in the payload; extra adds event-specific fields.
+func (uc *useCaseImpl) emit(ctx context.Context, eventType string, o *order.Order, extra map[string]interface{}) error {
+	payload := map[string]interface{}{
+		"order_id":      o.ID,
+		"user_id":       o.UserID,
+		"restaurant_id": o.RestaurantID,
+		"status":        string(o.Status),
+		"total":         o.Total.Decimal(),
+		"currency":      o.Currency(),
+	}
+	for k, v := range extra {
+		payload[k] = v
+	}
+
+	err := uc.publisher.Publish(ctx, messaging.Event{
+		Type:        eventType,
+		AggregateID: o.ID,
+		Payload:     payload,
+		Timestamp:   o.UpdatedAt.Unix(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", eventType, err)
+	}
+	return nil
+}
+
+// ListOrders lists orders matching the request's filters.
+// Filtering, sorting and pagination all happen in SQL so pages and totals are exact.
 func (uc *useCaseImpl) ListOrders(ctx context.Context, req ListOrdersRequest) ([]order.Order, int, error) {
 	// Validate and set defaults
 	if req.Page < 1 {
@@ -137,36 +1070,93 @@ func (uc *useCaseImpl) ListOrders(ctx context.Context, req ListOrdersRequest) ([
 		offset = (req.Page - 1) * req.Limit
 	}
 
-	var orders []order.Order
-	var total int
-	var err error
+	filter, err := uc.buildFilter(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	if order.ActorRole(req.ActorRole) == order.ActorRestaurant {
+		if err := uc.authorizeRestaurant(ctx, req.RestaurantID, req.ActorID, order.ActorRestaurant); err != nil {
+			return nil, 0, err
+		}
+	}
+	filter.Limit = req.Limit
+	filter.Offset = offset
 
-	// Filter by user_id if provided
-	if req.UserID != "" {
-		orders, err = uc.orderRepo.FindByUserID(ctx, req.UserID, req.Limit, offset)
-		if err != nil {
-			return nil, 0, fmt.Errorf("failed to fetch orders: %w", err)
+	orders, total, err := uc.orderRepo.FindByFilter(ctx, *filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch orders: %w", err)
+	}
+
+	return orders, total, nil
+}
+
+// buildFilter validates a list request and scopes it to what the caller may see.
+func (uc *useCaseImpl) buildFilter(req ListOrdersRequest) (*order.Filter, error) {
+	filter := &order.Filter{
+		UserID:        req.UserID,
+		RestaurantID:  req.RestaurantID,
+		CreatedFrom:   req.CreatedFrom,
+		CreatedTo:     req.CreatedTo,
+		PaymentMethod: req.PaymentMethod,
+		SortBy:        order.SortByCreatedAt,
+		SortDesc:      true,
+	}
+
+	switch order.ActorRole(req.ActorRole) {
+	case order.ActorAdmin:
+		// Admins may filter on anything
+	case order.ActorRestaurant:
+		if req.RestaurantID == "" {
+			return nil, fmt.Errorf("validation failed: restaurant_id is required")
 		}
+	case order.ActorCustomer:
+		if req.UserID != "" && req.UserID != req.ActorID {
+			return nil, order.ErrNotOrderOwner
+		}
+		filter.UserID = req.ActorID
+	default:
+		// Callers without a known role see no one's orders
+		return nil, order.ErrNotOrderOwner
+	}
+
+	for _, s := range req.Statuses {
+		status := order.OrderStatus(s)
+		if !status.IsValid() {
+			return nil, fmt.Errorf("validation failed: unknown status %q", s)
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
 
-		total, err = uc.orderRepo.CountByUserID(ctx, req.UserID)
+	if req.CreatedFrom != nil && req.CreatedTo != nil && !req.CreatedFrom.Before(*req.CreatedTo) {
+		return nil, fmt.Errorf("validation failed: created_from must be before created_to")
+	}
+
+	if req.MinTotal != "" {
+		minTotal, err := money.Parse(req.MinTotal, uc.currency)
+		if err != nil {
+			return nil, fmt.Errorf("validation failed: min_total: %w", err)
+		}
+		filter.MinTotal = &minTotal
+	}
+	if req.MaxTotal != "" {
+		maxTotal, err := money.Parse(req.MaxTotal, uc.currency)
 		if err != nil {
-			return nil, 0, fmt.Errorf("failed to count orders: %w", err)
+			return nil, fmt.Errorf("validation failed: max_total: %w", err)
 		}
+		filter.MaxTotal = &maxTotal
+	}
+	if filter.MinTotal != nil && filter.MaxTotal != nil && filter.MinTotal.Cmp(*filter.MaxTotal) > 0 {
+		return nil, fmt.Errorf("validation failed: min_total must not exceed max_total")
+	}
 
-		// Filter by status if provided
-		if req.Status != "" {
-			filtered := make([]order.Order, 0)
-			for _, o := range orders {
-				if string(o.Status) == req.Status {
-					filtered = append(filtered, o)
-				}
-			}
-			orders = filtered
+	if req.Sort != "" {
+		field, desc := strings.CutPrefix(req.Sort, "-")
+		filter.SortBy = order.SortField(field)
+		filter.SortDesc = desc
+		if !filter.SortBy.IsValid() {
+			return nil, fmt.Errorf("validation failed: cannot sort by %q", field)
 		}
-	} else {
-		// TODO: Implement FindAll if needed
-		return nil, 0, fmt.Errorf("user_id filter is required for listing orders")
 	}
 
-	return orders, total, nil
+	return filter, nil
 }
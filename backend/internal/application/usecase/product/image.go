@@ -0,0 +1,82 @@
+package product
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"foodie/backend/internal/domain/product"
+	imageutils "foodie/backend/pkg/utils/image"
+
+	"github.com/google/uuid"
+)
+
+// defaultThumbnailSize is used when no thumbnail size is configured.
+const defaultThumbnailSize = 320
+
+// UploadProductImage stores a new image and thumbnail for a product and then
+// deletes the previous ones. Every upload gets fresh keys, so stored blobs can
+// be cached forever and a failed upload never breaks the current image.
+func (uc *useCaseImpl) UploadProductImage(ctx context.Context, cmd UploadProductImageCommand) (*product.Product, error) {
+	if len(cmd.Data) == 0 {
+		return nil, fmt.Errorf("validation failed: image is required")
+	}
+	if uc.maxImageBytes > 0 && int64(len(cmd.Data)) > uc.maxImageBytes {
+		return nil, fmt.Errorf("validation failed: image must be at most %d bytes", uc.maxImageBytes)
+	}
+	contentType := imageutils.DetectContentType(cmd.Data)
+	ext, ok := imageutils.Extension(contentType)
+	if !ok {
+		return nil, fmt.Errorf("validation failed: unsupported image type %s; use JPEG, PNG or GIF", contentType)
+	}
+	thumbnailSize := uc.thumbnailSize
+	if thumbnailSize <= 0 {
+		thumbnailSize = defaultThumbnailSize
+	}
+	thumbnail, err := imageutils.Thumbnail(cmd.Data, thumbnailSize)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	p, err := uc.findOwnedProduct(ctx, cmd.ProductID, cmd.ActorID, cmd.ActorRole)
+	if err != nil {
+		return nil, err
+	}
+	if uc.images == nil {
+		return nil, fmt.Errorf("image storage is not configured")
+	}
+
+	key := fmt.Sprintf("products/%s/%s", p.ID, uuid.New().String())
+	imageURL, err := uc.images.Put(ctx, key+ext, bytes.NewReader(cmd.Data), contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store image: %w", err)
+	}
+	thumbnailURL, err := uc.images.Put(ctx, key+"_thumb.jpg", bytes.NewReader(thumbnail), "image/jpeg")
+	if err != nil {
+		uc.deleteImages(ctx, imageURL)
+		return nil, fmt.Errorf("failed to store thumbnail: %w", err)
+	}
+
+	if err := uc.productRepo.SetImage(ctx, p.ID, imageURL, thumbnailURL); err != nil {
+		uc.deleteImages(ctx, imageURL, thumbnailURL)
+		return nil, fmt.Errorf("failed to set product image: %w", err)
+	}
+	uc.deleteImages(ctx, p.ImageURL, p.ThumbnailURL)
+	uc.invalidate(ctx, p)
+
+	p.ImageURL = imageURL
+	p.ThumbnailURL = thumbnailURL
+	p.UpdatedAt = time.Now()
+	return p, nil
+}
+
+// deleteImages removes stored images by URL. Failures only leave orphaned
+// files behind, so they are not reported.
+func (uc *useCaseImpl) deleteImages(ctx context.Context, urls ...string) {
+	for _, url := range urls {
+		if key, ok := uc.images.KeyFromURL(url); ok {
+			_ = uc.images.Delete(ctx, key)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"foodie/backend/internal/domain/category"
+	"foodie/backend/internal/domain/product"
+	"foodie/backend/internal/domain/restaurant"
+
+	"github.com/google/uuid"
+)
+
+// menuCacheTTL bounds how stale a menu can be when an invalidation is lost,
+// and how long a sold-out flag set by orders may lag behind.
+const menuCacheTTL = 5 * time.Minute
+
+// GetMenu returns a restaurant's visible categories in menu order, each with
+// its products sorted by name. Products of hidden categories are left out;
+// products without a category are listed separately. Categories and products
+// outside their availability windows are left out too; since that changes by
+// the minute, the cached menu holds everything and is filtered on every read.
+func (uc *useCaseImpl) GetMenu(ctx context.Context, restaurantID string) (*Menu, error) {
+	if restaurantID == "" {
+		return nil, fmt.Errorf("validation failed: restaurant_id is required")
+	}
+
+	if uc.cache != nil {
+		cachedData, err := uc.cache.Get(ctx, menuCacheKey(restaurantID))
+		if err == nil && cachedData != nil {
+			var cached Menu
+			if json.Unmarshal(cachedData, &cached) == nil && cached.RestaurantID == restaurantID {
+				return cached.availableAt(time.Now(), uc.location), nil
+			}
+		}
+	}
+
+	menu, err := uc.buildMenu(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if uc.cache != nil {
+		if data, err := json.Marshal(menu); err == nil {
+			_ = uc.cache.Set(ctx, menuCacheKey(restaurantID), data, menuCacheTTL)
+		}
+	}
+	return menu.availableAt(time.Now(), uc.location), nil
+}
+
+// buildMenu assembles the category -> product tree from the database.
+func (uc *useCaseImpl) buildMenu(ctx context.Context, restaurantID string) (*Menu, error) {
+	categories, err := uc.categoryRepo.FindByRestaurant(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch categories: %w", err)
+	}
+	products, err := uc.productRepo.FindByRestaurant(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch products: %w", err)
+	}
+
+	byCategory := make(map[string][]product.Product, len(categories))
+	menu := &Menu{RestaurantID: restaurantID}
+	for _, p := range products {
+		if p.CategoryID == "" {
+			menu.Uncategorized = append(menu.Uncategorized, p)
+			continue
+		}
+		byCategory[p.CategoryID] = append(byCategory[p.CategoryID], p)
+	}
+
+	for _, c := range categories {
+		if !c.Visible {
+			continue
+		}
+		section := MenuSection{Category: c, Products: byCategory[c.ID]}
+		sortByName(section.Products)
+		menu.Sections = append(menu.Sections, section)
+	}
+	sortByName(menu.Uncategorized)
+	return menu, nil
+}
+
+// availableAt returns a copy of the menu without the categories and products
+// that cannot be ordered at t, reading availability windows in loc.
+func (m *Menu) availableAt(t time.Time, loc *time.Location) *Menu {
+	available := func(products []product.Product) []product.Product {
+		var kept []product.Product
+		for _, p := range products {
+			if p.IsAvailableAt(t, loc) {
+				kept = append(kept, p)
+			}
+		}
+		return kept
+	}
+
+	menu := &Menu{RestaurantID: m.RestaurantID, Uncategorized: available(m.Uncategorized)}
+	for _, section := range m.Sections {
+		if !section.Category.IsAvailableAt(t, loc) {
+			continue
+		}
+		menu.Sections = append(menu.Sections, MenuSection{Category: section.Category, Products: available(section.Products)})
+	}
+	return menu
+}
+
+// sortByName orders products the way the menu lists them.
+func sortByName(products []product.Product) {
+	sort.SliceStable(products, func(i, j int) bool {
+		return strings.ToLower(products[i].Name) < strings.ToLower(products[j].Name)
+	})
+}
+
+// CreateCategory adds a category to a restaurant's menu.
+// Only owners of the restaurant (or admins) may add categories.
+func (uc *useCaseImpl) CreateCategory(ctx context.Context, cmd CreateCategoryCommand) (*category.Category, error) {
+	if cmd.RestaurantID == "" {
+		return nil, fmt.Errorf("validation failed: restaurant_id is required")
+	}
+	if err := restaurant.Authorize(ctx, uc.ownershipRepo, cmd.RestaurantID, cmd.ActorID, cmd.ActorRole); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	c := &category.Category{
+		ID:           uuid.New().String(),
+		RestaurantID: cmd.RestaurantID,
+		Name:         strings.TrimSpace(cmd.Name),
+		SortOrder:    cmd.SortOrder,
+		Visible:      cmd.Visible,
+		Availability: cmd.Availability,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := uc.categoryRepo.Save(ctx, c); err != nil {
+		return nil, fmt.Errorf("failed to save category: %w", err)
+	}
+	uc.invalidateMenu(ctx, c.RestaurantID)
+	return c, nil
+}
+
+// UpdateCategory replaces a category's name, sort order, visibility and availability.
+func (uc *useCaseImpl) UpdateCategory(ctx context.Context, cmd UpdateCategoryCommand) (*category.Category, error) {
+	c, err := uc.findOwnedCategory(ctx, cmd.CategoryID, cmd.ActorID, cmd.ActorRole)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Name = strings.TrimSpace(cmd.Name)
+	c.SortOrder = cmd.SortOrder
+	c.Visible = cmd.Visible
+	c.Availability = cmd.Availability
+	c.UpdatedAt = time.Now()
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := uc.categoryRepo.Update(ctx, c); err != nil {
+		return nil, fmt.Errorf("failed to update category: %w", err)
+	}
+	uc.invalidateMenu(ctx, c.RestaurantID)
+	return c, nil
+}
+
+// DeleteCategory removes a category. Its products stay on the menu, uncategorized.
+func (uc *useCaseImpl) DeleteCategory(ctx context.Context, cmd DeleteCategoryCommand) error {
+	c, err := uc.findOwnedCategory(ctx, cmd.CategoryID, cmd.ActorID, cmd.ActorRole)
+	if err != nil {
+		return err
+	}
+
+	// Note the products first: the delete clears their category_id
+	products, err := uc.productRepo.FindByRestaurant(ctx, c.RestaurantID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch products: %w", err)
+	}
+
+	if err := uc.categoryRepo.Delete(ctx, c.ID); err != nil {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+
+	for _, p := range products {
+		if p.CategoryID == c.ID {
+			_ = uc.InvalidateProductCache(ctx, p.ID)
+		}
+	}
+	uc.invalidateMenu(ctx, c.RestaurantID)
+	return nil
+}
+
+// findOwnedCategory loads a category for a write, checking the actor may manage its restaurant.
+func (uc *useCaseImpl) findOwnedCategory(ctx context.Context, categoryID, actorID, actorRole string) (*category.Category, error) {
+	if categoryID == "" {
+		return nil, fmt.Errorf("validation failed: category_id is required")
+	}
+	c, err := uc.categoryRepo.FindByID(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	if err := restaurant.Authorize(ctx, uc.ownershipRepo, c.RestaurantID, actorID, actorRole); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// checkCategory rejects a product assigned to a category of another restaurant.
+func (uc *useCaseImpl) checkCategory(ctx context.Context, p *product.Product) error {
+	if p.CategoryID == "" {
+		return nil
+	}
+	c, err := uc.categoryRepo.FindByID(ctx, p.CategoryID)
+	if errors.Is(err, category.ErrNotFound) || (err == nil && c.RestaurantID != p.RestaurantID) {
+		return fmt.Errorf("validation failed: category %q is not on this restaurant's menu", p.CategoryID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch category: %w", err)
+	}
+	return nil
+}
+
+// invalidateMenu drops a restaurant's cached menu after a write; like
+// invalidate, failures are left to the cache TTL.
+func (uc *useCaseImpl) invalidateMenu(ctx context.Context, restaurantID string) {
+	_ = uc.InvalidateMenuCache(ctx, restaurantID)
+}
+
+// InvalidateMenuCache invalidates a restaurant's cached menu.
+func (uc *useCaseImpl) InvalidateMenuCache(ctx context.Context, restaurantID string) error {
+	if uc.cache == nil {
+		return nil
+	}
+	return uc.cache.Delete(ctx, menuCacheKey(restaurantID))
+}
+
+// menuCacheKey is the cache entry GetMenu populates for a restaurant.
+func menuCacheKey(restaurantID string) string {
+	return "menu:" + restaurantID
+}
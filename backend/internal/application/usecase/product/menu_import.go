@@ -0,0 +1,336 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"foodie/backend/internal/domain/category"
+	"foodie/backend/internal/domain/product"
+	"foodie/backend/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+// errDryRun rolls back the transaction of a dry-run import.
+var errDryRun = errors.New("dry run")
+
+// importPlan is a validated item, ready to be written.
+type importPlan struct {
+	row      int
+	category string // Category name, empty for none
+	product  *product.Product
+	existing *product.Product // Nil when the product is new
+}
+
+// ImportMenu validates every item first and reports all problems at once;
+// only a fully valid import is written. Items match existing products by ID,
+// then by case-insensitive name, and are updated in place; the rest are
+// created. A dry run performs the whole import in a transaction that is then
+// rolled back, so the report reflects what a real run would do.
+func (uc *useCaseImpl) ImportMenu(ctx context.Context, cmd ImportMenuCommand) (*ImportReport, error) {
+	if cmd.RestaurantID == "" {
+		return nil, fmt.Errorf("validation failed: restaurant_id is required")
+	}
+	if uc.txManager == nil {
+		return nil, fmt.Errorf("menu import requires a transaction manager")
+	}
+
+	categories, err := uc.categoryRepo.FindByRestaurant(ctx, cmd.RestaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch categories: %w", err)
+	}
+	existing, err := uc.productRepo.FindByRestaurant(ctx, cmd.RestaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch products: %w", err)
+	}
+
+	report := &ImportReport{}
+	plans := uc.planImport(cmd, existing, report)
+	if len(report.Errors) > 0 {
+		return report, nil
+	}
+
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		categoryIDs, err := uc.ensureCategories(ctx, cmd.RestaurantID, categories, plans, report)
+		if err != nil {
+			return err
+		}
+		for _, plan := range plans {
+			plan.product.CategoryID = categoryIDs[strings.ToLower(plan.category)]
+			if err := uc.writeImported(ctx, plan); err != nil {
+				return fmt.Errorf("row %d (%s): %w", plan.row, plan.product.Name, err)
+			}
+			if plan.existing == nil {
+				report.Created++
+			} else {
+				report.Updated++
+			}
+		}
+		if cmd.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if errors.Is(err, errDryRun) {
+		return report, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, plan := range plans {
+		_ = uc.InvalidateProductCache(ctx, plan.product.ID)
+	}
+	uc.invalidateMenu(ctx, cmd.RestaurantID)
+	return report, nil
+}
+
+// planImport validates items and matches them to existing products, adding
+// an error to report for every item that cannot be imported.
+func (uc *useCaseImpl) planImport(cmd ImportMenuCommand, existing []product.Product, report *ImportReport) []importPlan {
+	byID := make(map[string]*product.Product, len(existing))
+	byName := make(map[string]*product.Product, len(existing))
+	for i := range existing {
+		byID[existing[i].ID] = &existing[i]
+		byName[strings.ToLower(existing[i].Name)] = &existing[i]
+	}
+
+	seen := make(map[string]int) // Product ID, or name of a new product -> row that claimed it
+	now := time.Now()
+	plans := make([]importPlan, 0, len(cmd.Items))
+	for i, item := range cmd.Items {
+		row := i + 1
+		fail := func(format string, args ...any) {
+			report.Errors = append(report.Errors, ImportError{Row: row, Name: item.Name, Message: fmt.Sprintf(format, args...)})
+		}
+
+		name := strings.TrimSpace(item.Name)
+		match := byName[strings.ToLower(name)]
+		if item.ID != "" {
+			match = byID[item.ID]
+			if match == nil {
+				fail("product %s is not on this restaurant's menu", item.ID)
+				continue
+			}
+		}
+
+		categoryName := strings.TrimSpace(item.Category)
+		if categoryName != "" {
+			if err := (&category.Category{Name: categoryName}).Validate(); err != nil {
+				fail("category: %v", err)
+				continue
+			}
+		}
+
+		price, err := money.Parse(strings.TrimSpace(item.Price), uc.currency)
+		if err != nil {
+			fail("price: %v", err)
+			continue
+		}
+		stock, err := product.NewStock(product.StockMode(item.StockMode), item.Stock)
+		if err != nil {
+			fail("%v", err)
+			continue
+		}
+
+		p := &product.Product{
+			ID:             uuid.New().String(),
+			RestaurantID:   cmd.RestaurantID,
+			Name:           name,
+			Price:          price,
+			Stock:          stock,
+			ModifierGroups: item.ModifierGroups,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		if match != nil {
+			p.ID = match.ID
+			p.CreatedAt = match.CreatedAt
+			p.ImageURL = match.ImageURL
+			p.ThumbnailURL = match.ThumbnailURL
+			p.Availability = match.Availability // Not part of the import format
+			keepModifierIDs(p.ModifierGroups, match.ModifierGroups)
+		}
+		if err := uc.validate(p); err != nil {
+			fail("%s", strings.TrimPrefix(err.Error(), "validation failed: "))
+			continue
+		}
+
+		key := p.ID
+		if match == nil {
+			key = "new:" + strings.ToLower(p.Name)
+		}
+		if other, ok := seen[key]; ok {
+			fail("duplicates row %d", other)
+			continue
+		}
+		seen[key] = row
+
+		plans = append(plans, importPlan{
+			row:      row,
+			category: categoryName,
+			product:  p,
+			existing: match,
+		})
+	}
+	return plans
+}
+
+// ensureCategories creates the categories the import refers to that do not
+// exist yet, appended after the existing ones. It returns the ID of every
+// category keyed by lower-cased name.
+func (uc *useCaseImpl) ensureCategories(ctx context.Context, restaurantID string, categories []category.Category, plans []importPlan, report *ImportReport) (map[string]string, error) {
+	ids := make(map[string]string, len(categories))
+	nextSortOrder := 0
+	for _, c := range categories {
+		ids[strings.ToLower(c.Name)] = c.ID
+		nextSortOrder = max(nextSortOrder, c.SortOrder+1)
+	}
+
+	now := time.Now()
+	for _, plan := range plans {
+		key := strings.ToLower(plan.category)
+		if key == "" {
+			continue
+		}
+		if _, ok := ids[key]; ok {
+			continue
+		}
+		c := &category.Category{
+			ID:           uuid.New().String(),
+			RestaurantID: restaurantID,
+			Name:         plan.category,
+			SortOrder:    nextSortOrder,
+			Visible:      true,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+		if err := uc.categoryRepo.Save(ctx, c); err != nil {
+			return nil, fmt.Errorf("failed to save category %q: %w", c.Name, err)
+		}
+		ids[key] = c.ID
+		nextSortOrder++
+		report.CategoriesCreated++
+	}
+	return ids, nil
+}
+
+// writeImported saves a new product or updates an existing one, recording
+// new prices in the price history. Stock is only
+// reset when the import changes it, so re-importing a menu does not refill
+// daily quotas or undo sales.
+func (uc *useCaseImpl) writeImported(ctx context.Context, plan importPlan) error {
+	if plan.existing == nil {
+		if err := uc.productRepo.Save(ctx, plan.product); err != nil {
+			return fmt.Errorf("failed to save product: %w", err)
+		}
+		return uc.recordPrice(ctx, plan.product, "", plan.product.UpdatedAt)
+	}
+
+	if err := uc.productRepo.Update(ctx, plan.product); err != nil {
+		return fmt.Errorf("failed to update product: %w", err)
+	}
+	if plan.existing.Price != plan.product.Price {
+		if err := uc.recordPrice(ctx, plan.product, "", plan.product.UpdatedAt); err != nil {
+			return err
+		}
+	}
+	if plan.existing.Stock.Mode != plan.product.Stock.Mode || stockQuantity(plan.existing.Stock) != stockQuantity(plan.product.Stock) {
+		if err := uc.productRepo.SetStock(ctx, plan.product.ID, plan.product.Stock); err != nil {
+			return fmt.Errorf("failed to set product stock: %w", err)
+		}
+	}
+	return nil
+}
+
+// ExportMenu lists a restaurant's products by category order, then name.
+// Uncategorized products come last.
+func (uc *useCaseImpl) ExportMenu(ctx context.Context, restaurantID string) ([]MenuItem, error) {
+	if restaurantID == "" {
+		return nil, fmt.Errorf("validation failed: restaurant_id is required")
+	}
+
+	categories, err := uc.categoryRepo.FindByRestaurant(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch categories: %w", err)
+	}
+	products, err := uc.productRepo.FindByRestaurant(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch products: %w", err)
+	}
+
+	// Categories come back in menu order
+	names := make(map[string]string, len(categories))
+	position := make(map[string]int, len(categories))
+	for i, c := range categories {
+		names[c.ID] = c.Name
+		position[c.ID] = i
+	}
+	rank := func(p product.Product) int {
+		if pos, ok := position[p.CategoryID]; ok {
+			return pos
+		}
+		return len(categories)
+	}
+	sort.SliceStable(products, func(i, j int) bool {
+		if ri, rj := rank(products[i]), rank(products[j]); ri != rj {
+			return ri < rj
+		}
+		return strings.ToLower(products[i].Name) < strings.ToLower(products[j].Name)
+	})
+
+	items := make([]MenuItem, 0, len(products))
+	for _, p := range products {
+		items = append(items, MenuItem{
+			ID:             p.ID,
+			Category:       names[p.CategoryID],
+			Name:           p.Name,
+			Price:          p.Price.Decimal(),
+			StockMode:      string(p.Stock.Mode),
+			Stock:          stockQuantity(p.Stock),
+			ModifierGroups: p.ModifierGroups,
+		})
+	}
+	return items, nil
+}
+
+// keepModifierIDs gives imported groups and options without an ID the ID of
+// the existing one with the same name, so re-importing a file that does not
+// carry IDs keeps open carts and quotes valid.
+func keepModifierIDs(groups, existing []product.ModifierGroup) {
+	for i := range groups {
+		group := &groups[i]
+		for _, old := range existing {
+			if !strings.EqualFold(old.Name, group.Name) {
+				continue
+			}
+			if group.ID == "" {
+				group.ID = old.ID
+			}
+			for j := range group.Options {
+				option := &group.Options[j]
+				for _, oldOption := range old.Options {
+					if option.ID == "" && strings.EqualFold(oldOption.Name, option.Name) {
+						option.ID = oldOption.ID
+					}
+				}
+			}
+			break
+		}
+	}
+}
+
+// stockQuantity is the quantity NewStock would need to recreate stock.
+func stockQuantity(stock product.Stock) int {
+	switch stock.Mode {
+	case product.StockDaily:
+		return stock.DailyQuota
+	case product.StockCount:
+		return stock.Available
+	}
+	return 0
+}
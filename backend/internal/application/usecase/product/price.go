@@ -0,0 +1,129 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"foodie/backend/internal/domain/product"
+
+	"github.com/google/uuid"
+)
+
+// SchedulePriceChange records a future price for a product. The product keeps
+// its current price until the change takes effect; scheduling another change
+// for the same time replaces it.
+func (uc *useCaseImpl) SchedulePriceChange(ctx context.Context, cmd SchedulePriceChangeCommand) (*product.PriceChange, error) {
+	now := time.Now()
+	if !cmd.EffectiveFrom.After(now) {
+		return nil, fmt.Errorf("validation failed: effective_from must be in the future")
+	}
+
+	p, err := uc.findOwnedProduct(ctx, cmd.ProductID, cmd.ActorID, cmd.ActorRole)
+	if err != nil {
+		return nil, err
+	}
+
+	price, err := uc.inCurrency(cmd.Price)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if price.Currency != p.Price.Currency {
+		return nil, fmt.Errorf("validation failed: price must be in %s", p.Price.Currency)
+	}
+	change := &product.PriceChange{
+		ID:            uuid.New().String(),
+		ProductID:     p.ID,
+		Price:         price,
+		EffectiveFrom: cmd.EffectiveFrom,
+		CreatedBy:     cmd.ActorID,
+		CreatedAt:     now,
+	}
+	if err := change.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := uc.productRepo.SavePriceChange(ctx, change); err != nil {
+		return nil, fmt.Errorf("failed to save price change: %w", err)
+	}
+	return change, nil
+}
+
+// GetPriceHistory lists a product's prices, oldest first, each with the time
+// the next one replaced it. With At set only the price in effect then is
+// returned, answering "what did this cost last month".
+func (uc *useCaseImpl) GetPriceHistory(ctx context.Context, req PriceHistoryRequest) ([]PricePeriod, error) {
+	p, err := uc.findOwnedProduct(ctx, req.ProductID, req.ActorID, req.ActorRole)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := uc.productRepo.FindPriceHistory(ctx, p.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch price history: %w", err)
+	}
+
+	periods := make([]PricePeriod, 0, len(history))
+	for i, change := range history {
+		period := PricePeriod{PriceChange: change}
+		if i+1 < len(history) {
+			period.Until = &history[i+1].EffectiveFrom
+		}
+		periods = append(periods, period)
+	}
+
+	if req.At == nil {
+		return periods, nil
+	}
+	current, ok := product.PriceAt(history, *req.At)
+	if !ok {
+		return []PricePeriod{}, nil
+	}
+	for _, period := range periods {
+		if period.ID == current.ID {
+			return []PricePeriod{period}, nil
+		}
+	}
+	return []PricePeriod{}, nil
+}
+
+// ApplyScheduledPrices copies prices that have taken effect onto their
+// products and drops the cached copies, so caches and SQL price filters catch
+// up with reads, which resolve the effective price on their own.
+func (uc *useCaseImpl) ApplyScheduledPrices(ctx context.Context) (int, error) {
+	updated, err := uc.productRepo.ApplyDuePrices(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to apply scheduled prices: %w", err)
+	}
+	for i := range updated {
+		uc.invalidate(ctx, &updated[i])
+	}
+	return len(updated), nil
+}
+
+// recordPrice adds p's price to its history, effective and applied at once.
+// Call it within the transaction that writes the product.
+func (uc *useCaseImpl) recordPrice(ctx context.Context, p *product.Product, actorID string, at time.Time) error {
+	change := &product.PriceChange{
+		ID:            uuid.New().String(),
+		ProductID:     p.ID,
+		Price:         p.Price,
+		EffectiveFrom: at,
+		CreatedBy:     actorID,
+		CreatedAt:     at,
+		AppliedAt:     &at,
+	}
+	if err := uc.productRepo.SavePriceChange(ctx, change); err != nil {
+		return fmt.Errorf("failed to record price: %w", err)
+	}
+	return nil
+}
+
+// withinTransaction runs fn in a transaction when the use case has a
+// transaction manager, and directly otherwise.
+func (uc *useCaseImpl) withinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if uc.txManager == nil {
+		return fn(ctx)
+	}
+	return uc.txManager.WithinTransaction(ctx, fn)
+}
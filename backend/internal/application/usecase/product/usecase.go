@@ -2,8 +2,12 @@ package product
 
 import (
 	"context"
+	"time"
 
+	"foodie/backend/internal/domain/category"
 	"foodie/backend/internal/domain/product"
+	"foodie/backend/pkg/money"
+	timeutils "foodie/backend/pkg/utils/time"
 )
 
 // UseCase defines use cases for product management.
@@ -12,16 +16,230 @@ type UseCase interface {
 	ListProducts(ctx context.Context, req ListProductsRequest) ([]product.Product, int, error)
 
 	// GetProduct retrieves a product by ID.
+	// Returns product.ErrNotFound for unknown or deleted products.
 	GetProduct(ctx context.Context, productID string) (*product.Product, error)
 
+	// CreateProduct adds a product to a restaurant's menu.
+	CreateProduct(ctx context.Context, cmd CreateProductCommand) (*product.Product, error)
+
+	// UpdateProduct replaces the editable fields of a product.
+	UpdateProduct(ctx context.Context, cmd UpdateProductCommand) (*product.Product, error)
+
+	// DeleteProduct soft deletes a product so it can no longer be ordered.
+	DeleteProduct(ctx context.Context, cmd DeleteProductCommand) error
+
+	// SetProductStock changes how a product's stock is tracked and how much is left.
+	SetProductStock(ctx context.Context, cmd SetProductStockCommand) (*product.Product, error)
+
+	// UploadProductImage replaces a product's image and generates its thumbnail.
+	UploadProductImage(ctx context.Context, cmd UploadProductImageCommand) (*product.Product, error)
+
 	// InvalidateProductCache invalidates cached product data.
 	InvalidateProductCache(ctx context.Context, productID string) error
+
+	// GetMenu returns a restaurant's visible categories with the products that can be ordered now.
+	GetMenu(ctx context.Context, restaurantID string) (*Menu, error)
+
+	// CreateCategory adds a category to a restaurant's menu.
+	CreateCategory(ctx context.Context, cmd CreateCategoryCommand) (*category.Category, error)
+
+	// UpdateCategory replaces a category's name, sort order, visibility and availability.
+	UpdateCategory(ctx context.Context, cmd UpdateCategoryCommand) (*category.Category, error)
+
+	// DeleteCategory removes a category; its products stay on the menu uncategorized.
+	DeleteCategory(ctx context.Context, cmd DeleteCategoryCommand) error
+
+	// InvalidateMenuCache invalidates a restaurant's cached menu.
+	InvalidateMenuCache(ctx context.Context, restaurantID string) error
+
+	// ImportMenu creates or updates a restaurant's products in one transaction.
+	// When any item is invalid nothing is written and the report lists every error.
+	ImportMenu(ctx context.Context, cmd ImportMenuCommand) (*ImportReport, error)
+
+	// ExportMenu returns a restaurant's products in the form ImportMenu accepts.
+	ExportMenu(ctx context.Context, restaurantID string) ([]MenuItem, error)
+
+	// SchedulePriceChange sets a product's price from a future time on.
+	SchedulePriceChange(ctx context.Context, cmd SchedulePriceChangeCommand) (*product.PriceChange, error)
+
+	// GetPriceHistory lists the prices a product has had or is scheduled to have.
+	GetPriceHistory(ctx context.Context, req PriceHistoryRequest) ([]PricePeriod, error)
+
+	// ApplyScheduledPrices applies price changes that have taken effect and
+	// invalidates the affected cache entries. It returns how many products changed.
+	ApplyScheduledPrices(ctx context.Context) (int, error)
+
+	// ResetDailyStock refills daily products to their quota and invalidates
+	// the affected cache entries. It returns how many products were refilled.
+	ResetDailyStock(ctx context.Context) (int, error)
+}
+
+// CreateProductCommand represents the command to create a product.
+type CreateProductCommand struct {
+	ActorID      string
+	ActorRole    string
+	RestaurantID string
+	CategoryID   string // Optional; must be one of the restaurant's categories
+	Name         string
+	Price        money.Money // Currency defaults to the platform currency
+	StockMode    string      // unlimited (default), daily or count
+	Stock        int         // Daily quota or units on hand, depending on StockMode
+	// ModifierGroups without IDs get generated ones; deltas default to the platform currency.
+	ModifierGroups []product.ModifierGroup
+	// Availability limits when the product can be ordered; empty means always.
+	Availability []timeutils.Window
+}
+
+// UpdateProductCommand represents the command to update a product.
+type UpdateProductCommand struct {
+	ProductID  string
+	ActorID    string
+	ActorRole  string
+	CategoryID string // Empty leaves the product uncategorized
+	Name       string
+	Price      money.Money
+	// ModifierGroups replaces the product's modifiers. Keep existing IDs so
+	// open quotes and carts stay valid.
+	ModifierGroups []product.ModifierGroup
+	Availability   []timeutils.Window // Replaces the product's windows; empty means always available
+}
+
+// SetProductStockCommand represents the command to restock a product.
+type SetProductStockCommand struct {
+	ProductID string
+	ActorID   string
+	ActorRole string
+	Mode      string // unlimited, daily or count
+	Quantity  int    // Daily quota or units on hand, depending on Mode
+}
+
+// UploadProductImageCommand represents the command to upload a product image.
+type UploadProductImageCommand struct {
+	ProductID string
+	ActorID   string
+	ActorRole string
+	Data      []byte // Raw file; its content type is sniffed, not taken from the client
+}
+
+// DeleteProductCommand represents the command to delete a product.
+type DeleteProductCommand struct {
+	ProductID string
+	ActorID   string
+	ActorRole string
+}
+
+// SchedulePriceChangeCommand represents the command to schedule a price change.
+type SchedulePriceChangeCommand struct {
+	ProductID     string
+	ActorID       string
+	ActorRole     string
+	Price         money.Money // Currency defaults to the platform currency
+	EffectiveFrom time.Time   // Must be in the future
+}
+
+// PriceHistoryRequest represents the request for a product's price history.
+type PriceHistoryRequest struct {
+	ProductID string
+	ActorID   string
+	ActorRole string
+	At        *time.Time // Only the price in effect at this time
+}
+
+// PricePeriod is a price change and when the next one replaced it.
+type PricePeriod struct {
+	product.PriceChange
+	Until *time.Time // Nil for the latest change
 }
 
 // ListProductsRequest represents filters for listing products.
+// Every filter is optional; without restaurant_id the whole catalogue is listed.
 type ListProductsRequest struct {
 	RestaurantID string
-	Page         int // Page number (default: 1)
-	Offset       int // Offset (if provided, will be used directly; otherwise calculated from page)
-	Limit        int // Items per page (default: 20)
+	CategoryID   string
+	Name         string // Case-insensitive substring of the product name
+	MinPrice     string // Decimal amount in the platform currency, inclusive
+	MaxPrice     string // Decimal amount in the platform currency, inclusive
+	Sort         string // name, price or newest; prefix with "-" for descending
+	// IncludeUnavailable also lists products that cannot be ordered right now
+	// because of their, or their category's, availability windows.
+	IncludeUnavailable bool
+	Page               int // Page number (default: 1)
+	Offset             int // Offset (if provided, will be used directly; otherwise calculated from page)
+	Limit              int // Items per page (default: 20)
+}
+
+// Menu is a restaurant's menu: visible categories in order, each with its products.
+type Menu struct {
+	RestaurantID string
+	Sections     []MenuSection
+	// Uncategorized holds products not assigned to any category.
+	Uncategorized []product.Product
+}
+
+// MenuSection is one category of a menu and the products in it.
+type MenuSection struct {
+	Category category.Category
+	Products []product.Product
+}
+
+// CreateCategoryCommand represents the command to create a menu category.
+type CreateCategoryCommand struct {
+	ActorID      string
+	ActorRole    string
+	RestaurantID string
+	Name         string
+	SortOrder    int
+	Visible      bool
+	Availability []timeutils.Window // Empty means always available
+}
+
+// UpdateCategoryCommand represents the command to update a menu category.
+type UpdateCategoryCommand struct {
+	CategoryID   string
+	ActorID      string
+	ActorRole    string
+	Name         string
+	SortOrder    int
+	Visible      bool
+	Availability []timeutils.Window
+}
+
+// DeleteCategoryCommand represents the command to delete a menu category.
+type DeleteCategoryCommand struct {
+	CategoryID string
+	ActorID    string
+	ActorRole  string
+}
+
+// MenuItem is one product of a bulk menu import or export.
+type MenuItem struct {
+	ID             string // Optional on import; otherwise products are matched by name
+	Category       string // Category name; missing categories are created on import
+	Name           string
+	Price          string // Decimal amount in the platform currency
+	StockMode      string // unlimited (default), daily or count
+	Stock          int    // Daily quota or units on hand, depending on StockMode
+	ModifierGroups []product.ModifierGroup
+}
+
+// ImportMenuCommand represents the command to import a restaurant's menu.
+type ImportMenuCommand struct {
+	RestaurantID string
+	Items        []MenuItem
+	DryRun       bool // Validate and report without writing anything
+}
+
+// ImportReport summarizes a menu import.
+type ImportReport struct {
+	Created           int
+	Updated           int
+	CategoriesCreated int
+	Errors            []ImportError // Nothing was written when not empty
+}
+
+// ImportError describes why one item could not be imported.
+type ImportError struct {
+	Row     int // 1-based position of the item in the import
+	Name    string
+	Message string
 }
@@ -4,44 +4,152 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
+	"foodie/backend/internal/domain/category"
 	"foodie/backend/internal/domain/product"
+	"foodie/backend/internal/domain/restaurant"
 	"foodie/backend/internal/infrastructure/cache"
+	"foodie/backend/internal/infrastructure/database/txn"
+	"foodie/backend/internal/infrastructure/storage"
+	"foodie/backend/pkg/money"
+	timeutils "foodie/backend/pkg/utils/time"
+
+	"github.com/google/uuid"
 )
 
+// Dependencies groups the collaborators of the product use case.
+type Dependencies struct {
+	ProductRepo  product.Repository
+	CategoryRepo category.Repository
+	// OwnershipRepo decides which restaurant accounts may edit a menu.
+	OwnershipRepo restaurant.OwnershipRepository
+	// Cache is optional; when set, GetProduct and GetMenu read through it and writes invalidate it.
+	Cache cache.Cache
+	// Currency is the platform currency every product is priced in.
+	Currency string
+	// Images stores uploaded product images; uploads fail when it is nil.
+	Images storage.BlobStorage
+	// MaxImageBytes caps the size of an uploaded image.
+	MaxImageBytes int64
+	// ThumbnailSize is the longest side of generated thumbnails, in pixels.
+	ThumbnailSize int
+	// TxManager makes menu imports all-or-nothing and records price history
+	// together with the edits; imports fail when it is nil.
+	TxManager txn.Manager
+	// Location is the restaurants' time zone, in which availability windows
+	// are read. Defaults to UTC.
+	Location *time.Location
+}
+
 // useCaseImpl implements the UseCase interface.
 type useCaseImpl struct {
-	productRepo product.Repository
-	cache       cache.Cache // Optional cache, can be nil
+	productRepo   product.Repository
+	categoryRepo  category.Repository
+	ownershipRepo restaurant.OwnershipRepository
+	cache         cache.Cache // Optional cache, can be nil
+	currency      string
+	images        storage.BlobStorage
+	maxImageBytes int64
+	thumbnailSize int
+	txManager     txn.Manager
+	location      *time.Location
 }
 
 // NewUseCase creates a new product use case.
-func NewUseCase(productRepo product.Repository) UseCase {
+func NewUseCase(deps Dependencies) UseCase {
 	return &useCaseImpl{
-		productRepo: productRepo,
+		productRepo:   deps.ProductRepo,
+		categoryRepo:  deps.CategoryRepo,
+		ownershipRepo: deps.OwnershipRepo,
+		cache:         deps.Cache,
+		currency:      deps.Currency,
+		images:        deps.Images,
+		maxImageBytes: deps.MaxImageBytes,
+		thumbnailSize: deps.ThumbnailSize,
+		txManager:     deps.TxManager,
+		location:      deps.Location,
 	}
 }
 
-// NewUseCaseWithCache creates a new product use case with cache support.
-func NewUseCaseWithCache(productRepo product.Repository, cache cache.Cache) UseCase {
-	return &useCaseImpl{
-		productRepo: productRepo,
-		cache:       cache,
+// ListProducts lists products with optional filters.
+// Filtering, sorting and pagination happen in the database.
+func (uc *useCaseImpl) ListProducts(ctx context.Context, req ListProductsRequest) ([]product.Product, int, error) {
+	// Validate and set defaults
+	if req.Page < 1 {
+		req.Page = 1
 	}
+	if req.Limit < 1 || req.Limit > 100 {
+		req.Limit = 20
+	}
+
+	// Calculate offset: use provided offset if specified, otherwise calculate from page
+	offset := req.Offset
+	if offset == 0 && req.Page > 0 {
+		offset = (req.Page - 1) * req.Limit
+	}
+
+	filter, err := uc.buildFilter(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	filter.Limit = req.Limit
+	filter.Offset = offset
+
+	products, total, err := uc.productRepo.FindByFilter(ctx, *filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch products: %w", err)
+	}
+
+	return products, total, nil
 }
 
-// ListProducts lists products with optional filters.
-func (uc *useCaseImpl) ListProducts(ctx context.Context, req ListProductsRequest) ([]product.Product, int, error) {
-	// TODO: Implement filtering and pagination
-	if req.RestaurantID != "" {
-		products, err := uc.productRepo.FindByRestaurant(ctx, req.RestaurantID)
+// buildFilter validates list parameters and turns them into a product filter.
+func (uc *useCaseImpl) buildFilter(req ListProductsRequest) (*product.Filter, error) {
+	filter := &product.Filter{
+		RestaurantID: req.RestaurantID,
+		CategoryID:   req.CategoryID,
+		Name:         strings.TrimSpace(req.Name),
+		SortBy:       product.SortByName,
+	}
+	if !req.IncludeUnavailable {
+		now := timeutils.MomentIn(time.Now(), uc.location)
+		filter.AvailableAt = &now
+	}
+
+	if req.MinPrice != "" {
+		minPrice, err := money.Parse(req.MinPrice, uc.currency)
 		if err != nil {
-			return nil, 0, err
+			return nil, fmt.Errorf("validation failed: min_price: %w", err)
 		}
-		return products, len(products), nil
+		filter.MinPrice = &minPrice
+	}
+	if req.MaxPrice != "" {
+		maxPrice, err := money.Parse(req.MaxPrice, uc.currency)
+		if err != nil {
+			return nil, fmt.Errorf("validation failed: max_price: %w", err)
+		}
+		filter.MaxPrice = &maxPrice
+	}
+	if filter.MinPrice != nil && filter.MaxPrice != nil && filter.MinPrice.Cmp(*filter.MaxPrice) > 0 {
+		return nil, fmt.Errorf("validation failed: min_price must not exceed max_price")
 	}
-	return nil, 0, fmt.Errorf("not implemented: need to implement FindAll or similar")
+
+	if req.Sort != "" {
+		field, desc := strings.CutPrefix(req.Sort, "-")
+		filter.SortBy = product.SortField(field)
+		filter.SortDesc = desc
+		if !filter.SortBy.IsValid() {
+			return nil, fmt.Errorf("validation failed: cannot sort by %q", field)
+		}
+		// "newest" reads naturally only one way round
+		if filter.SortBy == product.SortByNewest {
+			filter.SortDesc = !desc
+		}
+	}
+
+	return filter, nil
 }
 
 // GetProduct retrieves a product by ID.
@@ -49,7 +157,7 @@ func (uc *useCaseImpl) ListProducts(ctx context.Context, req ListProductsRequest
 func (uc *useCaseImpl) GetProduct(ctx context.Context, productID string) (*product.Product, error) {
 	// Try cache first if available
 	if uc.cache != nil {
-		cacheKey := "product:" + productID
+		cacheKey := productCacheKey(productID)
 
 		// Try to get from cache
 		cachedData, err := uc.cache.Get(ctx, cacheKey)
@@ -80,6 +188,210 @@ func (uc *useCaseImpl) GetProduct(ctx context.Context, productID string) (*produ
 	return uc.productRepo.FindByID(ctx, productID)
 }
 
+// CreateProduct adds a product to a restaurant's menu.
+// Only owners of the restaurant (or admins) may add products.
+func (uc *useCaseImpl) CreateProduct(ctx context.Context, cmd CreateProductCommand) (*product.Product, error) {
+	if cmd.RestaurantID == "" {
+		return nil, fmt.Errorf("validation failed: restaurant_id is required")
+	}
+	if err := restaurant.Authorize(ctx, uc.ownershipRepo, cmd.RestaurantID, cmd.ActorID, cmd.ActorRole); err != nil {
+		return nil, err
+	}
+
+	stock, err := product.NewStock(product.StockMode(cmd.StockMode), cmd.Stock)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	now := time.Now()
+	p := &product.Product{
+		ID:             uuid.New().String(),
+		RestaurantID:   cmd.RestaurantID,
+		CategoryID:     cmd.CategoryID,
+		Name:           strings.TrimSpace(cmd.Name),
+		Price:          cmd.Price,
+		Stock:          stock,
+		ModifierGroups: cmd.ModifierGroups,
+		Availability:   cmd.Availability,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := uc.validate(p); err != nil {
+		return nil, err
+	}
+	if err := uc.checkCategory(ctx, p); err != nil {
+		return nil, err
+	}
+
+	err = uc.withinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.productRepo.Save(ctx, p); err != nil {
+			return fmt.Errorf("failed to save product: %w", err)
+		}
+		return uc.recordPrice(ctx, p, cmd.ActorID, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.invalidateMenu(ctx, p.RestaurantID)
+	return p, nil
+}
+
+// UpdateProduct replaces a product's category, name, price, modifiers and availability.
+// Only owners of the product's restaurant (or admins) may edit it. A new price
+// applies immediately; scheduled price changes still take effect later.
+func (uc *useCaseImpl) UpdateProduct(ctx context.Context, cmd UpdateProductCommand) (*product.Product, error) {
+	p, err := uc.findOwnedProduct(ctx, cmd.ProductID, cmd.ActorID, cmd.ActorRole)
+	if err != nil {
+		return nil, err
+	}
+
+	previousPrice := p.Price
+	p.CategoryID = cmd.CategoryID
+	p.Name = strings.TrimSpace(cmd.Name)
+	p.Price = cmd.Price
+	p.ModifierGroups = cmd.ModifierGroups
+	p.Availability = cmd.Availability
+	p.UpdatedAt = time.Now()
+	if err := uc.validate(p); err != nil {
+		return nil, err
+	}
+	if err := uc.checkCategory(ctx, p); err != nil {
+		return nil, err
+	}
+
+	err = uc.withinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.productRepo.Update(ctx, p); err != nil {
+			return fmt.Errorf("failed to update product: %w", err)
+		}
+		if p.Price == previousPrice {
+			return nil
+		}
+		return uc.recordPrice(ctx, p, cmd.ActorID, p.UpdatedAt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.invalidate(ctx, p)
+	return p, nil
+}
+
+// DeleteProduct soft deletes a product. Past orders keep their line items,
+// but the product disappears from listings and can no longer be ordered.
+func (uc *useCaseImpl) DeleteProduct(ctx context.Context, cmd DeleteProductCommand) error {
+	p, err := uc.findOwnedProduct(ctx, cmd.ProductID, cmd.ActorID, cmd.ActorRole)
+	if err != nil {
+		return err
+	}
+
+	p.Delete(time.Now())
+	if err := uc.productRepo.Update(ctx, p); err != nil {
+		return fmt.Errorf("failed to delete product: %w", err)
+	}
+	uc.invalidate(ctx, p)
+	return nil
+}
+
+// SetProductStock replaces a product's stock. Setting a daily quota makes the
+// full quota available immediately; the scheduler refills it every night.
+func (uc *useCaseImpl) SetProductStock(ctx context.Context, cmd SetProductStockCommand) (*product.Product, error) {
+	stock, err := product.NewStock(product.StockMode(cmd.Mode), cmd.Quantity)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	p, err := uc.findOwnedProduct(ctx, cmd.ProductID, cmd.ActorID, cmd.ActorRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.productRepo.SetStock(ctx, p.ID, stock); err != nil {
+		return nil, fmt.Errorf("failed to set product stock: %w", err)
+	}
+	uc.invalidate(ctx, p)
+
+	p.Stock = stock
+	p.UpdatedAt = time.Now()
+	return p, nil
+}
+
+// ResetDailyStock refills daily products to their quota and drops their
+// cached copies, so refilled items stop showing as sold out.
+func (uc *useCaseImpl) ResetDailyStock(ctx context.Context) (int, error) {
+	reset, err := uc.productRepo.ResetDailyStock(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reset daily stock: %w", err)
+	}
+	for i := range reset {
+		uc.invalidate(ctx, &reset[i])
+	}
+	return len(reset), nil
+}
+
+// findOwnedProduct loads a product for a write, checking the actor may manage its restaurant.
+// The product is read from the database, never the cache, so writes start from current data.
+func (uc *useCaseImpl) findOwnedProduct(ctx context.Context, productID, actorID, actorRole string) (*product.Product, error) {
+	if productID == "" {
+		return nil, fmt.Errorf("validation failed: product_id is required")
+	}
+	p, err := uc.productRepo.FindByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if err := restaurant.Authorize(ctx, uc.ownershipRepo, p.RestaurantID, actorID, actorRole); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// validate checks a product before it is written and fills in defaults:
+// modifier groups and options get IDs, and amounts without a currency are in
+// the platform currency. Any other currency is rejected, since orders are
+// priced in a single currency.
+func (uc *useCaseImpl) validate(p *product.Product) error {
+	var err error
+	if p.Price, err = uc.inCurrency(p.Price); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	for i := range p.ModifierGroups {
+		group := &p.ModifierGroups[i]
+		if group.ID == "" {
+			group.ID = uuid.New().String()
+		}
+		for j := range group.Options {
+			option := &group.Options[j]
+			if option.ID == "" {
+				option.ID = uuid.New().String()
+			}
+			if option.PriceDelta, err = uc.inCurrency(option.PriceDelta); err != nil {
+				return fmt.Errorf("validation failed: %w", err)
+			}
+		}
+	}
+	if uc.currency != "" && p.Price.Currency != uc.currency {
+		return fmt.Errorf("validation failed: price must be in %s", uc.currency)
+	}
+	if err := p.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	return nil
+}
+
+// inCurrency puts an amount given without a currency into the platform currency.
+func (uc *useCaseImpl) inCurrency(m money.Money) (money.Money, error) {
+	if uc.currency == "" {
+		return m, nil
+	}
+	return m.WithCurrency(uc.currency), nil
+}
+
+// invalidate drops the cached copy of a product, and the menu it appears on,
+// after a write. A failure is not reported to the caller: the write has
+// succeeded and the entries expire on their own within the cache TTL.
+func (uc *useCaseImpl) invalidate(ctx context.Context, p *product.Product) {
+	_ = uc.InvalidateProductCache(ctx, p.ID)
+	uc.invalidateMenu(ctx, p.RestaurantID)
+}
+
 // InvalidateProductCache invalidates cached product data.
 // This should be called when product is updated or deleted.
 func (uc *useCaseImpl) InvalidateProductCache(ctx context.Context, productID string) error {
@@ -87,6 +399,10 @@ func (uc *useCaseImpl) InvalidateProductCache(ctx context.Context, productID str
 		return nil
 	}
 
-	cacheKey := "product:" + productID
-	return uc.cache.Delete(ctx, cacheKey)
+	return uc.cache.Delete(ctx, productCacheKey(productID))
+}
+
+// productCacheKey is the cache entry GetProduct populates for a product.
+func productCacheKey(productID string) string {
+	return "product:" + productID
 }
@@ -0,0 +1,75 @@
+package restaurant
+
+import (
+	"context"
+
+	"foodie/backend/internal/domain/restaurant"
+	timeutils "foodie/backend/pkg/utils/time"
+)
+
+// UseCase defines use cases for restaurant management.
+type UseCase interface {
+	// ListRestaurants lists restaurants by name with optional filters.
+	ListRestaurants(ctx context.Context, req ListRestaurantsRequest) ([]restaurant.Restaurant, int, error)
+
+	// GetRestaurant retrieves a restaurant by ID.
+	// Returns restaurant.ErrNotFound for unknown or deleted restaurants.
+	GetRestaurant(ctx context.Context, restaurantID string) (*restaurant.Restaurant, error)
+
+	// CreateRestaurant registers a restaurant and makes its owner able to manage it.
+	CreateRestaurant(ctx context.Context, cmd CreateRestaurantCommand) (*restaurant.Restaurant, error)
+
+	// UpdateRestaurant replaces the editable fields of a restaurant.
+	UpdateRestaurant(ctx context.Context, cmd UpdateRestaurantCommand) (*restaurant.Restaurant, error)
+
+	// DeleteRestaurant soft deletes a restaurant so it can no longer be ordered from.
+	DeleteRestaurant(ctx context.Context, cmd DeleteRestaurantCommand) error
+}
+
+// CreateRestaurantCommand represents the command to create a restaurant.
+type CreateRestaurantCommand struct {
+	ActorID   string
+	ActorRole string
+	// OwnerID is the account that will manage the restaurant. Admins must set
+	// it; restaurant accounts always own what they create.
+	OwnerID      string
+	Name         string
+	Address      string
+	Location     restaurant.Location
+	TimeZone     string // Default: the platform time zone
+	Status       string // open (default), paused or closed
+	OpeningHours []timeutils.Window
+}
+
+// UpdateRestaurantCommand represents the command to update a restaurant.
+type UpdateRestaurantCommand struct {
+	RestaurantID string
+	ActorID      string
+	ActorRole    string
+	Name         string
+	Address      string
+	Location     restaurant.Location
+	TimeZone     string // Empty keeps the current time zone
+	Status       string // Empty keeps the current status
+	OpeningHours []timeutils.Window
+}
+
+// DeleteRestaurantCommand represents the command to delete a restaurant.
+type DeleteRestaurantCommand struct {
+	RestaurantID string
+	ActorID      string
+	ActorRole    string
+}
+
+// ListRestaurantsRequest represents filters for listing restaurants.
+type ListRestaurantsRequest struct {
+	Name string // Case-insensitive substring of the restaurant name
+	// Statuses keeps restaurants in any of these statuses. Default: open and
+	// paused; closed restaurants are only listed when asked for.
+	Statuses []string
+	OwnerID  string // Restaurants this account owns
+
+	Page   int // Page number (default: 1)
+	Offset int // Offset (if provided, will be used directly; otherwise calculated from page)
+	Limit  int // Items per page (default: 20)
+}
@@ -0,0 +1,192 @@
+package restaurant
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"foodie/backend/internal/domain/restaurant"
+
+	"github.com/google/uuid"
+)
+
+// Dependencies groups the collaborators of the restaurant use case.
+type Dependencies struct {
+	RestaurantRepo restaurant.Repository
+	// OwnershipRepo decides which restaurant accounts may edit a restaurant.
+	OwnershipRepo restaurant.OwnershipRepository
+	// Location is the time zone new restaurants get when none is given.
+	// Defaults to UTC.
+	Location *time.Location
+}
+
+// useCaseImpl implements the UseCase interface.
+type useCaseImpl struct {
+	restaurantRepo restaurant.Repository
+	ownershipRepo  restaurant.OwnershipRepository
+	location       *time.Location
+}
+
+// NewUseCase creates a new restaurant use case.
+func NewUseCase(deps Dependencies) UseCase {
+	location := deps.Location
+	if location == nil {
+		location = time.UTC
+	}
+	return &useCaseImpl{
+		restaurantRepo: deps.RestaurantRepo,
+		ownershipRepo:  deps.OwnershipRepo,
+		location:       location,
+	}
+}
+
+// ListRestaurants lists restaurants with optional filters.
+// Filtering and pagination happen in the database.
+func (uc *useCaseImpl) ListRestaurants(ctx context.Context, req ListRestaurantsRequest) ([]restaurant.Restaurant, int, error) {
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.Limit < 1 || req.Limit > 100 {
+		req.Limit = 20
+	}
+	offset := req.Offset
+	if offset == 0 && req.Page > 0 {
+		offset = (req.Page - 1) * req.Limit
+	}
+
+	filter := restaurant.Filter{
+		Name:     strings.TrimSpace(req.Name),
+		Statuses: []restaurant.Status{restaurant.StatusOpen, restaurant.StatusPaused},
+		OwnerID:  req.OwnerID,
+		Limit:    req.Limit,
+		Offset:   offset,
+	}
+	if len(req.Statuses) > 0 {
+		filter.Statuses = nil
+		for _, s := range req.Statuses {
+			status := restaurant.Status(strings.TrimSpace(s))
+			if !status.IsValid() {
+				return nil, 0, fmt.Errorf("validation failed: invalid status %q", s)
+			}
+			filter.Statuses = append(filter.Statuses, status)
+		}
+	}
+
+	restaurants, total, err := uc.restaurantRepo.FindByFilter(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch restaurants: %w", err)
+	}
+	return restaurants, total, nil
+}
+
+// GetRestaurant retrieves a restaurant by ID.
+func (uc *useCaseImpl) GetRestaurant(ctx context.Context, restaurantID string) (*restaurant.Restaurant, error) {
+	if restaurantID == "" {
+		return nil, restaurant.ErrNotFound
+	}
+	return uc.restaurantRepo.FindByID(ctx, restaurantID)
+}
+
+// CreateRestaurant registers a restaurant. Restaurant accounts become the
+// owner of what they create; admins create restaurants on behalf of an owner.
+func (uc *useCaseImpl) CreateRestaurant(ctx context.Context, cmd CreateRestaurantCommand) (*restaurant.Restaurant, error) {
+	ownerID := cmd.OwnerID
+	switch cmd.ActorRole {
+	case "admin":
+		if ownerID == "" {
+			return nil, fmt.Errorf("validation failed: owner_id is required")
+		}
+	case "restaurant":
+		if ownerID != "" && ownerID != cmd.ActorID {
+			return nil, restaurant.ErrNotOwner
+		}
+		ownerID = cmd.ActorID
+	default:
+		return nil, restaurant.ErrNotOwner
+	}
+
+	now := time.Now()
+	r := &restaurant.Restaurant{
+		ID:           uuid.New().String(),
+		Name:         strings.TrimSpace(cmd.Name),
+		Address:      strings.TrimSpace(cmd.Address),
+		Location:     cmd.Location,
+		TimeZone:     cmd.TimeZone,
+		Status:       restaurant.Status(cmd.Status),
+		OpeningHours: cmd.OpeningHours,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if r.TimeZone == "" {
+		r.TimeZone = uc.location.String()
+	}
+	if r.Status == "" {
+		r.Status = restaurant.StatusOpen
+	}
+	if err := r.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := uc.restaurantRepo.Save(ctx, r, ownerID); err != nil {
+		return nil, fmt.Errorf("failed to save restaurant: %w", err)
+	}
+	return r, nil
+}
+
+// UpdateRestaurant replaces a restaurant's details, status and opening hours.
+func (uc *useCaseImpl) UpdateRestaurant(ctx context.Context, cmd UpdateRestaurantCommand) (*restaurant.Restaurant, error) {
+	r, err := uc.findOwnedRestaurant(ctx, cmd.RestaurantID, cmd.ActorID, cmd.ActorRole)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Name = strings.TrimSpace(cmd.Name)
+	r.Address = strings.TrimSpace(cmd.Address)
+	r.Location = cmd.Location
+	if cmd.TimeZone != "" {
+		r.TimeZone = cmd.TimeZone
+	}
+	if cmd.Status != "" {
+		r.Status = restaurant.Status(cmd.Status)
+	}
+	r.OpeningHours = cmd.OpeningHours
+	r.UpdatedAt = time.Now()
+	if err := r.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := uc.restaurantRepo.Update(ctx, r); err != nil {
+		return nil, fmt.Errorf("failed to update restaurant: %w", err)
+	}
+	return r, nil
+}
+
+// DeleteRestaurant soft deletes a restaurant. Its orders are kept; new ones are refused.
+func (uc *useCaseImpl) DeleteRestaurant(ctx context.Context, cmd DeleteRestaurantCommand) error {
+	r, err := uc.findOwnedRestaurant(ctx, cmd.RestaurantID, cmd.ActorID, cmd.ActorRole)
+	if err != nil {
+		return err
+	}
+
+	r.Delete(time.Now())
+	if err := uc.restaurantRepo.Update(ctx, r); err != nil {
+		return fmt.Errorf("failed to delete restaurant: %w", err)
+	}
+	return nil
+}
+
+// findOwnedRestaurant loads a restaurant for a write, checking the actor may manage it.
+func (uc *useCaseImpl) findOwnedRestaurant(ctx context.Context, restaurantID, actorID, actorRole string) (*restaurant.Restaurant, error) {
+	if restaurantID == "" {
+		return nil, fmt.Errorf("validation failed: restaurant id is required")
+	}
+	r, err := uc.restaurantRepo.FindByID(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if err := restaurant.Authorize(ctx, uc.ownershipRepo, r.ID, actorID, actorRole); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
@@ -0,0 +1,37 @@
+package search
+
+import (
+	"context"
+
+	"foodie/backend/internal/domain/product"
+	"foodie/backend/internal/domain/restaurant"
+)
+
+// UseCase defines use cases for searching the catalogue.
+type UseCase interface {
+	// Search finds products by name and restaurants by name or address, most
+	// relevant first.
+	Search(ctx context.Context, req SearchRequest) (*Results, error)
+}
+
+// Results is one page of matching products and the restaurants matching the
+// same query.
+type Results struct {
+	Products     []product.SearchResult
+	ProductTotal int // Matching products across all pages
+	// Restaurants holds the best matching restaurants on the first page only,
+	// and never when the search is limited to one restaurant.
+	Restaurants     []restaurant.SearchResult
+	RestaurantTotal int
+}
+
+// SearchRequest represents a search with optional filters.
+type SearchRequest struct {
+	Query        string // Required; each word matches as a prefix
+	RestaurantID string // Searches one restaurant's menu; no restaurants are returned
+	MinPrice     string // Decimal amount in the platform currency, inclusive; products only
+	MaxPrice     string // Decimal amount in the platform currency, inclusive; products only
+	Page         int    // Page number (default: 1)
+	Offset       int    // Offset (if provided, will be used directly; otherwise calculated from page)
+	Limit        int    // Items per page (default: 20)
+}
@@ -0,0 +1,122 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"foodie/backend/internal/domain/product"
+	"foodie/backend/internal/domain/restaurant"
+	"foodie/backend/pkg/money"
+	timeutils "foodie/backend/pkg/utils/time"
+)
+
+// maxQueryLength keeps pathological queries away from the database.
+const maxQueryLength = 200
+
+// maxRestaurantResults is how many restaurants are returned next to the
+// first page of products.
+const maxRestaurantResults = 5
+
+// Dependencies groups the collaborators of the search use case.
+type Dependencies struct {
+	ProductRepo    product.Repository
+	RestaurantRepo restaurant.Repository
+	// Currency is the platform currency price filters are given in.
+	Currency string
+	// Location is the restaurants' time zone, in which availability windows
+	// are read. Defaults to UTC.
+	Location *time.Location
+}
+
+// useCaseImpl implements the UseCase interface.
+type useCaseImpl struct {
+	productRepo    product.Repository
+	restaurantRepo restaurant.Repository
+	currency       string
+	location       *time.Location
+}
+
+// NewUseCase creates a new search use case.
+func NewUseCase(deps Dependencies) UseCase {
+	return &useCaseImpl{
+		productRepo:    deps.ProductRepo,
+		restaurantRepo: deps.RestaurantRepo,
+		currency:       deps.Currency,
+		location:       deps.Location,
+	}
+}
+
+// Search finds products by name, most relevant first. Only products that can
+// be ordered now, by their availability windows, are found. The first page
+// also lists the best matching restaurants that are not closed.
+func (uc *useCaseImpl) Search(ctx context.Context, req SearchRequest) (*Results, error) {
+	text := strings.TrimSpace(req.Query)
+	if text == "" {
+		return nil, fmt.Errorf("validation failed: q is required")
+	}
+	if len(text) > maxQueryLength {
+		return nil, fmt.Errorf("validation failed: q must be at most %d characters", maxQueryLength)
+	}
+
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.Limit < 1 || req.Limit > 100 {
+		req.Limit = 20
+	}
+	offset := req.Offset
+	if offset == 0 {
+		offset = (req.Page - 1) * req.Limit
+	}
+
+	now := timeutils.MomentIn(time.Now(), uc.location)
+	query := product.SearchQuery{
+		Text:         text,
+		RestaurantID: req.RestaurantID,
+		AvailableAt:  &now,
+		Limit:        req.Limit,
+		Offset:       offset,
+	}
+	var err error
+	if query.MinPrice, err = uc.parsePrice("min_price", req.MinPrice); err != nil {
+		return nil, err
+	}
+	if query.MaxPrice, err = uc.parsePrice("max_price", req.MaxPrice); err != nil {
+		return nil, err
+	}
+	if query.MinPrice != nil && query.MaxPrice != nil && query.MinPrice.Cmp(*query.MaxPrice) > 0 {
+		return nil, fmt.Errorf("validation failed: min_price must not exceed max_price")
+	}
+
+	results := &Results{}
+	results.Products, results.ProductTotal, err = uc.productRepo.Search(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search products: %w", err)
+	}
+
+	if offset == 0 && req.RestaurantID == "" {
+		results.Restaurants, results.RestaurantTotal, err = uc.restaurantRepo.Search(ctx, restaurant.SearchQuery{
+			Text:     text,
+			Statuses: []restaurant.Status{restaurant.StatusOpen, restaurant.StatusPaused},
+			Limit:    maxRestaurantResults,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to search restaurants: %w", err)
+		}
+	}
+	return results, nil
+}
+
+// parsePrice reads an optional price filter in the platform currency.
+func (uc *useCaseImpl) parsePrice(field, value string) (*money.Money, error) {
+	if value == "" {
+		return nil, nil
+	}
+	price, err := money.Parse(value, uc.currency)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %s: %w", field, err)
+	}
+	return &price, nil
+}
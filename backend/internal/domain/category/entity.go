@@ -0,0 +1,48 @@
+package category
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	timeutils "foodie/backend/pkg/utils/time"
+)
+
+// Category groups products on a restaurant's menu, e.g. "Starters" or "Drinks".
+type Category struct {
+	ID           string
+	RestaurantID string
+	Name         string
+	SortOrder    int  // Lower values are listed first
+	Visible      bool // Hidden categories, and their products, are left off the public menu
+	// Availability limits when the category's products can be ordered, on top
+	// of their own windows. Empty means always.
+	Availability []timeutils.Window
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// ErrNotFound is returned when a category does not exist.
+var ErrNotFound = errors.New("category not found")
+
+// maxNameLength matches the categories.name column.
+const maxNameLength = 100
+
+// Validate checks the fields a restaurant can edit.
+func (c *Category) Validate() error {
+	name := strings.TrimSpace(c.Name)
+	if name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(name) > maxNameLength {
+		return fmt.Errorf("name must be at most %d characters", maxNameLength)
+	}
+	return timeutils.ValidateWindows(c.Availability)
+}
+
+// IsAvailableAt reports whether the category's availability windows allow
+// ordering its products at t, read in the restaurant's time zone loc.
+func (c *Category) IsAvailableAt(t time.Time, loc *time.Location) bool {
+	return timeutils.InAnyWindow(c.Availability, t, loc)
+}
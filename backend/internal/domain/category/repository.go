@@ -0,0 +1,16 @@
+package category
+
+import "context"
+
+// Repository defines storage operations for menu categories.
+type Repository interface {
+	Save(ctx context.Context, category *Category) error
+	// Update returns ErrNotFound if the category does not exist.
+	Update(ctx context.Context, category *Category) error
+	// Delete removes a category; its products become uncategorized.
+	Delete(ctx context.Context, id string) error
+	// FindByID returns ErrNotFound for unknown categories.
+	FindByID(ctx context.Context, id string) (*Category, error)
+	// FindByRestaurant returns a restaurant's categories in menu order.
+	FindByRestaurant(ctx context.Context, restaurantID string) ([]Category, error)
+}
@@ -0,0 +1,98 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ActorRole identifies who is acting on an order.
+// Values match the roles carried in the authenticated request context.
+type ActorRole string
+
+const (
+	ActorCustomer   ActorRole = "user"
+	ActorRestaurant ActorRole = "restaurant"
+	ActorAdmin      ActorRole = "admin"
+	// ActorSystem is used for changes made by the platform itself, e.g. a declined payment.
+	ActorSystem ActorRole = "system"
+)
+
+// CancellationReason is a machine-readable code explaining why an order was cancelled.
+type CancellationReason string
+
+const (
+	ReasonCustomerRequest     CancellationReason = "customer_request"
+	ReasonItemUnavailable     CancellationReason = "item_unavailable"
+	ReasonRestaurantClosed    CancellationReason = "restaurant_closed"
+	ReasonRestaurantTooBusy   CancellationReason = "restaurant_too_busy"
+	ReasonDeliveryUnavailable CancellationReason = "delivery_unavailable"
+	ReasonSuspectedFraud      CancellationReason = "suspected_fraud"
+	ReasonOther               CancellationReason = "other"
+)
+
+// IsValid reports whether r is a known cancellation reason.
+func (r CancellationReason) IsValid() bool {
+	switch r {
+	case ReasonCustomerRequest, ReasonItemUnavailable, ReasonRestaurantClosed,
+		ReasonRestaurantTooBusy, ReasonDeliveryUnavailable, ReasonSuspectedFraud, ReasonOther:
+		return true
+	}
+	return false
+}
+
+// cancellableStatuses lists, per actor role, the statuses from which that role may cancel.
+// Customers can only back out before the kitchen starts; restaurants can cancel until
+// the order leaves for delivery; admins follow the full state machine.
+// Orders whose payment failed can be cleaned up by anyone.
+var cancellableStatuses = map[ActorRole][]OrderStatus{
+	ActorCustomer:   {StatusPending, StatusConfirmed, StatusPaymentFailed},
+	ActorRestaurant: {StatusPending, StatusConfirmed, StatusPreparing, StatusReady, StatusPaymentFailed},
+	ActorAdmin:      {StatusPending, StatusConfirmed, StatusPreparing, StatusReady, StatusPaymentFailed},
+}
+
+// CanBeCancelledBy reports whether an order in status s may be cancelled by role.
+func (s OrderStatus) CanBeCancelledBy(role ActorRole) bool {
+	for _, allowed := range cancellableStatuses[role] {
+		if allowed == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Cancellation records who cancelled an order and why.
+type Cancellation struct {
+	By     string
+	Role   ActorRole
+	Reason CancellationReason
+	Note   string
+	At     time.Time
+}
+
+// Cancel moves the order to cancelled on behalf of the given actor.
+// It returns *CancellationNotAllowedError if the actor's role may not cancel
+// the order in its current status.
+func (o *Order) Cancel(c Cancellation) error {
+	if !o.Status.CanBeCancelledBy(c.Role) {
+		return &CancellationNotAllowedError{Status: o.Status, Role: c.Role}
+	}
+	if err := o.TransitionTo(StatusCancelled, c.At); err != nil {
+		return err
+	}
+	o.Cancellation = &c
+	return nil
+}
+
+// CancellationNotAllowedError is returned when a role cannot cancel an order in its current status.
+type CancellationNotAllowedError struct {
+	Status OrderStatus
+	Role   ActorRole
+}
+
+func (e *CancellationNotAllowedError) Error() string {
+	return fmt.Sprintf("order in status %q cannot be cancelled by %q", e.Status, e.Role)
+}
+
+// ErrNotOrderOwner is returned when a customer acts on an order placed by someone else.
+var ErrNotOrderOwner = errors.New("order does not belong to user")
@@ -0,0 +1,84 @@
+package order
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"foodie/backend/pkg/money"
+)
+
+// FeeTier charges Fee for deliveries up to and including UpToKm kilometres.
+type FeeTier struct {
+	UpToKm float64
+	Fee    money.Money
+}
+
+// FeeSchedule is a distance-based delivery fee schedule, sorted by UpToKm.
+// Distances beyond the last tier are outside the delivery area.
+type FeeSchedule []FeeTier
+
+// DefaultFeeSchedule is used when no schedule is configured.
+const DefaultFeeSchedule = "3:1.99,7:3.49,15:5.99"
+
+// ParseFeeSchedule parses a schedule written as comma-separated "km:fee" pairs
+// with fees in currency, e.g. "3:1.99,7:3.49,15:5.99" charges 1.99 up to 3 km,
+// 3.49 up to 7 km and 5.99 up to 15 km.
+func ParseFeeSchedule(s, currency string) (FeeSchedule, error) {
+	var schedule FeeSchedule
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		km, fee, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid fee tier %q: expected km:fee", part)
+		}
+		upTo, err := strconv.ParseFloat(strings.TrimSpace(km), 64)
+		if err != nil || upTo <= 0 {
+			return nil, fmt.Errorf("invalid fee tier %q: distance must be a positive number", part)
+		}
+		amount, err := money.Parse(fee, currency)
+		if err != nil || amount.IsNegative() {
+			return nil, fmt.Errorf("invalid fee tier %q: fee must be a non-negative number", part)
+		}
+		schedule = append(schedule, FeeTier{UpToKm: upTo, Fee: amount})
+	}
+	if len(schedule) == 0 {
+		return nil, fmt.Errorf("fee schedule must have at least one tier")
+	}
+
+	sort.Slice(schedule, func(i, j int) bool { return schedule[i].UpToKm < schedule[j].UpToKm })
+	return schedule, nil
+}
+
+// MaxDistanceKm returns the edge of the delivery area.
+func (s FeeSchedule) MaxDistanceKm() float64 {
+	if len(s) == 0 {
+		return 0
+	}
+	return s[len(s)-1].UpToKm
+}
+
+// FeeFor returns the delivery fee for a distance in kilometres.
+// It returns *OutOfDeliveryRangeError if the distance exceeds the last tier.
+func (s FeeSchedule) FeeFor(distanceKm float64) (money.Money, error) {
+	for _, tier := range s {
+		if distanceKm <= tier.UpToKm {
+			return tier.Fee, nil
+		}
+	}
+	return money.Money{}, &OutOfDeliveryRangeError{DistanceKm: distanceKm, MaxDistanceKm: s.MaxDistanceKm()}
+}
+
+// OutOfDeliveryRangeError is returned when the delivery address is too far from the restaurant.
+type OutOfDeliveryRangeError struct {
+	DistanceKm    float64
+	MaxDistanceKm float64
+}
+
+func (e *OutOfDeliveryRangeError) Error() string {
+	return fmt.Sprintf("delivery address is %.1f km away, the delivery area ends at %.1f km", e.DistanceKm, e.MaxDistanceKm)
+}
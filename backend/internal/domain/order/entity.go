@@ -1,6 +1,10 @@
 package order
 
-import "time"
+import (
+	"time"
+
+	"foodie/backend/pkg/money"
+)
 
 // OrderStatus represents the status of an order.
 type OrderStatus string
@@ -13,26 +17,93 @@ const (
 	StatusDelivering OrderStatus = "delivering"
 	StatusCompleted  OrderStatus = "completed"
 	StatusCancelled  OrderStatus = "cancelled"
+	// StatusPaymentFailed marks an order whose payment could not be authorized.
+	// It never reaches the restaurant and can only be cancelled.
+	StatusPaymentFailed OrderStatus = "payment_failed"
+)
+
+// PaymentStatus summarises the state of the payment attached to an order.
+type PaymentStatus string
+
+const (
+	PaymentUnpaid     PaymentStatus = "unpaid"
+	PaymentAuthorized PaymentStatus = "authorized"
+	PaymentCaptured   PaymentStatus = "captured"
+	PaymentRefunded   PaymentStatus = "refunded"
+	PaymentFailed     PaymentStatus = "failed"
 )
 
+// IsRefundable reports whether money has been taken or reserved and must be returned on cancellation.
+func (s PaymentStatus) IsRefundable() bool {
+	return s == PaymentAuthorized || s == PaymentCaptured
+}
+
 // OrderItem represents an item in an order.
+// Price is the product's base price; chosen modifiers adjust it per unit.
 type OrderItem struct {
 	ProductID   string
 	ProductName string
 	Quantity    int
-	Price       float64
+	Price       money.Money
+	Modifiers   []ItemModifier `json:",omitempty"`
+}
+
+// ItemModifier is a modifier option chosen for an item, e.g. "Size: Large".
+// Names and price are copied at ordering time so later menu edits do not
+// change past orders.
+type ItemModifier struct {
+	GroupID    string
+	GroupName  string
+	OptionID   string
+	OptionName string
+	PriceDelta money.Money
+}
+
+// UnitPrice returns the price of one unit including its modifiers.
+func (i OrderItem) UnitPrice() money.Money {
+	price := i.Price
+	for _, m := range i.Modifiers {
+		price = price.Add(m.PriceDelta)
+	}
+	return price
+}
+
+// LineTotal returns the unit price of the item times its quantity.
+func (i OrderItem) LineTotal() money.Money {
+	return i.UnitPrice().Mul(i.Quantity)
 }
 
 // Order represents a food order aggregate in the domain layer.
 type Order struct {
-	ID              string
-	UserID          string
-	RestaurantID    string
-	Status          OrderStatus
-	Items           []OrderItem
-	Total           float64
+	ID           string
+	UserID       string
+	RestaurantID string
+	Status       OrderStatus
+	Items        []OrderItem
+	// Subtotal, Discount, ServiceFee, Tax and DeliveryFee add up to Total.
+	// All amounts share the order's currency.
+	Subtotal        money.Money
+	Discount        money.Money
+	ServiceFee      money.Money
+	Tax             money.Money
+	Total           money.Money
+	PromoCode       string
 	PaymentMethod   string
+	PaymentID       string
+	PaymentStatus   PaymentStatus
 	DeliveryAddress string
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
+	DeliveryFee     money.Money
+	DistanceKm      float64
+	// PrepTimeMinutes is set when the restaurant accepts the order.
+	PrepTimeMinutes int
+	// EstimatedDeliveryAt is nil for orders placed before ETAs were quoted.
+	EstimatedDeliveryAt *time.Time
+	Cancellation        *Cancellation
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// Currency returns the currency the order is charged in.
+func (o *Order) Currency() string {
+	return o.Total.Currency
 }
@@ -0,0 +1,14 @@
+package order
+
+// Domain event types emitted over the order lifecycle.
+// They double as message broker routing keys (see messaging.RabbitMQPublisher).
+const (
+	EventCreated   = "order.created"
+	EventCancelled = "order.cancelled"
+)
+
+// StatusEventType returns the event type emitted when an order enters status,
+// e.g. "order.confirmed" or "order.completed".
+func StatusEventType(status OrderStatus) string {
+	return "order." + string(status)
+}
@@ -0,0 +1,42 @@
+package order
+
+import (
+	"time"
+
+	"foodie/backend/pkg/money"
+)
+
+// SortField is a column orders can be sorted by.
+type SortField string
+
+const (
+	SortByCreatedAt SortField = "created_at"
+	SortByUpdatedAt SortField = "updated_at"
+	SortByTotal     SortField = "total"
+)
+
+// IsValid reports whether f is a known sort field.
+func (f SortField) IsValid() bool {
+	switch f {
+	case SortByCreatedAt, SortByUpdatedAt, SortByTotal:
+		return true
+	}
+	return false
+}
+
+// Filter selects orders for listing. Zero-valued fields do not filter.
+type Filter struct {
+	UserID        string
+	RestaurantID  string
+	Statuses      []OrderStatus
+	CreatedFrom   *time.Time // Inclusive
+	CreatedTo     *time.Time // Exclusive
+	PaymentMethod string
+	MinTotal      *money.Money
+	MaxTotal      *money.Money
+
+	SortBy   SortField // Default: created_at
+	SortDesc bool
+	Limit    int
+	Offset   int
+}
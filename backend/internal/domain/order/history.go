@@ -0,0 +1,16 @@
+package order
+
+import "time"
+
+// StatusChange is one entry in an order's status timeline.
+// FromStatus is empty for the entry recorded when the order is created.
+type StatusChange struct {
+	ID         int64
+	OrderID    string
+	FromStatus OrderStatus
+	ToStatus   OrderStatus
+	ActorID    string
+	ActorRole  ActorRole
+	Note       string
+	CreatedAt  time.Time
+}
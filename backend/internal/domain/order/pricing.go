@@ -0,0 +1,104 @@
+package order
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"foodie/backend/pkg/money"
+)
+
+// PriceBreakdown itemises how an order total is made up.
+// Total = Subtotal - Discount + DeliveryFee + ServiceFee + Tax.
+type PriceBreakdown struct {
+	Subtotal    money.Money
+	Discount    money.Money
+	DeliveryFee money.Money
+	ServiceFee  money.Money
+	Tax         money.Money
+	Total       money.Money
+}
+
+// Promotion is a promo code that takes either a percentage or a fixed amount off the subtotal.
+type Promotion struct {
+	Code       string
+	PercentOff float64
+	AmountOff  money.Money
+}
+
+// DiscountFor returns the discount the promotion gives on subtotal, never more than subtotal.
+func (p Promotion) DiscountFor(subtotal money.Money) money.Money {
+	if p.PercentOff > 0 {
+		return subtotal.Percent(p.PercentOff).Min(subtotal)
+	}
+	return p.AmountOff.Min(subtotal)
+}
+
+// ParsePromotions parses promo codes written as comma-separated "CODE=value" pairs,
+// where value is either a percentage ("10%") or a fixed amount in currency ("5.00"),
+// e.g. "WELCOME10=10%,SAVE5=5.00". Codes are case-insensitive.
+func ParsePromotions(s, currency string) (map[string]Promotion, error) {
+	promotions := make(map[string]Promotion)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		code, value, ok := strings.Cut(part, "=")
+		code = strings.ToUpper(strings.TrimSpace(code))
+		value = strings.TrimSpace(value)
+		if !ok || code == "" {
+			return nil, fmt.Errorf("invalid promotion %q: expected CODE=value", part)
+		}
+
+		promo := Promotion{Code: code}
+		if percent, isPercent := strings.CutSuffix(value, "%"); isPercent {
+			v, err := strconv.ParseFloat(percent, 64)
+			if err != nil || v <= 0 || v > 100 {
+				return nil, fmt.Errorf("invalid promotion %q: percentage must be between 0 and 100", part)
+			}
+			promo.PercentOff = v
+		} else {
+			amount, err := money.Parse(value, currency)
+			if err != nil || !amount.IsPositive() {
+				return nil, fmt.Errorf("invalid promotion %q: amount must be a positive number", part)
+			}
+			promo.AmountOff = amount
+		}
+		promotions[code] = promo
+	}
+	return promotions, nil
+}
+
+// PricingPolicy holds the fees, taxes and promotions applied on top of item prices.
+type PricingPolicy struct {
+	// ServiceFeePercent is charged on the subtotal.
+	ServiceFeePercent float64
+	// TaxPercent is charged on the discounted subtotal plus the service fee.
+	TaxPercent float64
+	Promotions map[string]Promotion
+}
+
+// Promotion looks up a promo code. It returns false if the code is unknown.
+func (p PricingPolicy) Promotion(code string) (Promotion, bool) {
+	promo, ok := p.Promotions[strings.ToUpper(strings.TrimSpace(code))]
+	return promo, ok
+}
+
+// Price computes the breakdown for an items subtotal and delivery fee,
+// applying promo if it is not nil. Percentages round half away from zero
+// to the currency's minor unit.
+func (p PricingPolicy) Price(subtotal, deliveryFee money.Money, promo *Promotion) PriceBreakdown {
+	b := PriceBreakdown{
+		Subtotal:    subtotal,
+		Discount:    money.Zero(subtotal.Currency),
+		DeliveryFee: deliveryFee,
+		ServiceFee:  subtotal.Percent(p.ServiceFeePercent),
+	}
+	if promo != nil {
+		b.Discount = promo.DiscountFor(subtotal)
+	}
+	b.Tax = subtotal.Sub(b.Discount).Add(b.ServiceFee).Percent(p.TaxPercent)
+	b.Total = subtotal.Sub(b.Discount).Add(b.DeliveryFee).Add(b.ServiceFee).Add(b.Tax)
+	return b
+}
@@ -5,7 +5,17 @@ import "context"
 // Repository defines the storage operations required by the Order use cases.
 type Repository interface {
 	Save(ctx context.Context, order *Order) error
+	// Update writes the order's mutable fields if it is still in status
+	// previous, and returns ErrStatusChanged otherwise.
+	Update(ctx context.Context, order *Order, previous OrderStatus) error
 	FindByID(ctx context.Context, id string) (*Order, error)
 	FindByUserID(ctx context.Context, userID string, limit, offset int) ([]Order, error)
 	CountByUserID(ctx context.Context, userID string) (int, error)
+	// FindByFilter returns one page of orders matching filter and the total number of matches.
+	FindByFilter(ctx context.Context, filter Filter) ([]Order, int, error)
+
+	// SaveStatusChange appends an entry to the order's status timeline.
+	SaveStatusChange(ctx context.Context, change *StatusChange) error
+	// FindStatusHistory returns an order's status timeline, oldest first.
+	FindStatusHistory(ctx context.Context, orderID string) ([]StatusChange, error)
 }
@@ -0,0 +1,67 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// transitions lists the statuses an order may move to from each status.
+// Terminal statuses (completed, cancelled) have no outgoing transitions.
+var transitions = map[OrderStatus][]OrderStatus{
+	StatusPending:       {StatusConfirmed, StatusCancelled, StatusPaymentFailed},
+	StatusPaymentFailed: {StatusCancelled},
+	StatusConfirmed:     {StatusPreparing, StatusCancelled},
+	StatusPreparing:     {StatusReady, StatusCancelled},
+	StatusReady:         {StatusDelivering, StatusCancelled},
+	StatusDelivering:    {StatusCompleted},
+	StatusCompleted:     {},
+	StatusCancelled:     {},
+}
+
+// IsValid reports whether s is a known order status.
+func (s OrderStatus) IsValid() bool {
+	_, ok := transitions[s]
+	return ok
+}
+
+// IsTerminal reports whether no further transitions are possible from s.
+func (s OrderStatus) IsTerminal() bool {
+	return len(transitions[s]) == 0
+}
+
+// CanTransitionTo reports whether an order in status s may move to next.
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionTo moves the order to the next status, updating UpdatedAt.
+// It returns *InvalidTransitionError if the move is not allowed.
+func (o *Order) TransitionTo(next OrderStatus, now time.Time) error {
+	if !o.Status.CanTransitionTo(next) {
+		return &InvalidTransitionError{From: o.Status, To: next}
+	}
+	o.Status = next
+	o.UpdatedAt = now
+	return nil
+}
+
+// InvalidTransitionError is returned when an order cannot move between two statuses.
+type InvalidTransitionError struct {
+	From OrderStatus
+	To   OrderStatus
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("invalid status transition from %q to %q", e.From, e.To)
+}
+
+// ErrStatusChanged is returned when an order is written back after another
+// request changed its status, e.g. two concurrent cancellations. The losing
+// request must not act on the status it read.
+var ErrStatusChanged = errors.New("order status changed concurrently")
@@ -0,0 +1,103 @@
+package order
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var allStatuses = []OrderStatus{
+	StatusPending,
+	StatusPaymentFailed,
+	StatusConfirmed,
+	StatusPreparing,
+	StatusReady,
+	StatusDelivering,
+	StatusCompleted,
+	StatusCancelled,
+}
+
+func TestTransitions(t *testing.T) {
+	allowed := map[OrderStatus][]OrderStatus{
+		StatusPending:       {StatusConfirmed, StatusCancelled, StatusPaymentFailed},
+		StatusPaymentFailed: {StatusCancelled},
+		StatusConfirmed:     {StatusPreparing, StatusCancelled},
+		StatusPreparing:     {StatusReady, StatusCancelled},
+		StatusReady:         {StatusDelivering, StatusCancelled},
+		StatusDelivering:    {StatusCompleted},
+	}
+
+	for _, from := range allStatuses {
+		for _, to := range allStatuses {
+			want := false
+			for _, next := range allowed[from] {
+				if next == to {
+					want = true
+				}
+			}
+			if got := from.CanTransitionTo(to); got != want {
+				t.Errorf("%s -> %s: CanTransitionTo = %v, want %v", from, to, got, want)
+			}
+		}
+	}
+}
+
+func TestTerminalStatusesRejectEveryTransition(t *testing.T) {
+	for _, from := range allStatuses {
+		terminal := from == StatusCompleted || from == StatusCancelled
+		if got := from.IsTerminal(); got != terminal {
+			t.Errorf("%s: IsTerminal = %v, want %v", from, got, terminal)
+		}
+		if !terminal {
+			continue
+		}
+		for _, to := range allStatuses {
+			if from.CanTransitionTo(to) {
+				t.Errorf("terminal %s may move to %s", from, to)
+			}
+		}
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	for _, s := range allStatuses {
+		if !s.IsValid() {
+			t.Errorf("%s: IsValid = false", s)
+		}
+	}
+	for _, s := range []OrderStatus{"", "shipped", "PENDING"} {
+		if s.IsValid() {
+			t.Errorf("%q: IsValid = true", s)
+		}
+	}
+}
+
+func TestTransitionTo(t *testing.T) {
+	created := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := created.Add(time.Minute)
+
+	t.Run("allowed", func(t *testing.T) {
+		o := &Order{Status: StatusConfirmed, UpdatedAt: created}
+		if err := o.TransitionTo(StatusPreparing, now); err != nil {
+			t.Fatalf("TransitionTo: %v", err)
+		}
+		if o.Status != StatusPreparing || !o.UpdatedAt.Equal(now) {
+			t.Fatalf("got %s at %v, want %s at %v", o.Status, o.UpdatedAt, StatusPreparing, now)
+		}
+	})
+
+	t.Run("not allowed", func(t *testing.T) {
+		o := &Order{Status: StatusCompleted, UpdatedAt: created}
+		err := o.TransitionTo(StatusPreparing, now)
+		var transitionErr *InvalidTransitionError
+		if !errors.As(err, &transitionErr) {
+			t.Fatalf("TransitionTo error %v, want *InvalidTransitionError", err)
+		}
+		if transitionErr.From != StatusCompleted || transitionErr.To != StatusPreparing {
+			t.Fatalf("error reports %s -> %s, want completed -> preparing", transitionErr.From, transitionErr.To)
+		}
+		if o.Status != StatusCompleted || !o.UpdatedAt.Equal(created) {
+			t.Fatalf("order changed to %s at %v, want it untouched", o.Status, o.UpdatedAt)
+		}
+	})
+}
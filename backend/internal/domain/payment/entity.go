@@ -0,0 +1,117 @@
+package payment
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"foodie/backend/pkg/money"
+)
+
+// Status represents the state of a payment.
+type Status string
+
+const (
+	StatusPending    Status = "pending"
+	StatusAuthorized Status = "authorized"
+	StatusCaptured   Status = "captured"
+	StatusFailed     Status = "failed"
+	StatusRefunded   Status = "refunded"
+)
+
+// MethodCash is paid to the courier on delivery and never goes through the gateway.
+const MethodCash = "cash"
+
+// Payment represents the money attached to one order.
+type Payment struct {
+	ID                   string
+	OrderID              string
+	Method               string
+	Amount               money.Money
+	Status               Status
+	GatewayPaymentID     string
+	GatewayTransactionID string
+	FailureReason        string
+	AuthorizedAt         *time.Time
+	CapturedAt           *time.Time
+	RefundedAt           *time.Time
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+// RequiresGateway reports whether the payment is processed by the payment gateway.
+func (p *Payment) RequiresGateway() bool {
+	return p.Method != MethodCash
+}
+
+// IsRefundable reports whether money has been reserved or taken and must be returned.
+func (p *Payment) IsRefundable() bool {
+	return p.Status == StatusAuthorized || p.Status == StatusCaptured
+}
+
+// IdempotencyKey names one gateway operation on the payment, e.g. "capture".
+// The gateway treats calls with the same key as one, so retrying after a
+// failed database write can never charge or refund twice.
+func (p *Payment) IdempotencyKey(operation string) string {
+	return p.ID + ":" + operation
+}
+
+// Authorize records a successful gateway authorization.
+func (p *Payment) Authorize(gatewayPaymentID, gatewayTransactionID string, now time.Time) error {
+	if p.Status != StatusPending {
+		return &InvalidStatusError{Status: p.Status, Action: "authorize"}
+	}
+	p.Status = StatusAuthorized
+	p.GatewayPaymentID = gatewayPaymentID
+	p.GatewayTransactionID = gatewayTransactionID
+	p.AuthorizedAt = &now
+	p.UpdatedAt = now
+	return nil
+}
+
+// Fail records a declined or errored authorization.
+func (p *Payment) Fail(reason string, now time.Time) error {
+	if p.Status != StatusPending {
+		return &InvalidStatusError{Status: p.Status, Action: "fail"}
+	}
+	p.Status = StatusFailed
+	p.FailureReason = reason
+	p.UpdatedAt = now
+	return nil
+}
+
+// Capture records that the funds were collected.
+// Gateway payments must be authorized first; cash is captured straight from pending.
+func (p *Payment) Capture(now time.Time) error {
+	if p.Status != StatusAuthorized && !(p.Status == StatusPending && !p.RequiresGateway()) {
+		return &InvalidStatusError{Status: p.Status, Action: "capture"}
+	}
+	p.Status = StatusCaptured
+	p.CapturedAt = &now
+	p.UpdatedAt = now
+	return nil
+}
+
+// Refund records that reserved or collected funds were returned.
+func (p *Payment) Refund(now time.Time) error {
+	if !p.IsRefundable() {
+		return &InvalidStatusError{Status: p.Status, Action: "refund"}
+	}
+	p.Status = StatusRefunded
+	p.RefundedAt = &now
+	p.UpdatedAt = now
+	return nil
+}
+
+// InvalidStatusError is returned when an action is not allowed in the payment's current status.
+type InvalidStatusError struct {
+	Status Status
+	Action string
+}
+
+func (e *InvalidStatusError) Error() string {
+	return fmt.Sprintf("cannot %s payment in status %q", e.Action, e.Status)
+}
+
+// ErrNotFound is returned when no payment exists for the requested lookup.
+var ErrNotFound = errors.New("payment not found")
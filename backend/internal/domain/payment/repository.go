@@ -0,0 +1,13 @@
+package payment
+
+import "context"
+
+// Repository defines storage operations for payments.
+type Repository interface {
+	Save(ctx context.Context, p *Payment) error
+	Update(ctx context.Context, p *Payment) error
+	FindByID(ctx context.Context, id string) (*Payment, error)
+	// FindByOrderID returns ErrNotFound if the order has no payment,
+	// e.g. orders placed before payments were tracked.
+	FindByOrderID(ctx context.Context, orderID string) (*Payment, error)
+}
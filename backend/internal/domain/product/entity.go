@@ -1,12 +1,93 @@
 package product
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"foodie/backend/pkg/money"
+	timeutils "foodie/backend/pkg/utils/time"
+)
 
 // Product represents a product/item in the restaurant menu.
 type Product struct {
 	ID           string
 	RestaurantID string
+	CategoryID   string // Empty when the product is uncategorized
 	Name         string
-	Price        float64
+	Price        money.Money
+	Stock        Stock
+	ImageURL     string // Empty until an image is uploaded
+	ThumbnailURL string
+	// ModifierGroups are the choices offered with the product, e.g. size or toppings.
+	ModifierGroups []ModifierGroup
+	// Availability limits when the product can be ordered, in the restaurant's
+	// time zone, e.g. breakfast items in the morning. Empty means always.
+	Availability []timeutils.Window
 	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    *time.Time // Set when the product is removed from the menu
+}
+
+// ErrNotFound is returned when a product does not exist or has been deleted.
+var ErrNotFound = errors.New("product not found")
+
+// maxNameLength matches the products.name column.
+const maxNameLength = 255
+
+// Validate checks the fields a restaurant can edit.
+func (p *Product) Validate() error {
+	name := strings.TrimSpace(p.Name)
+	if name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(name) > maxNameLength {
+		return fmt.Errorf("name must be at most %d characters", maxNameLength)
+	}
+	if !p.Price.IsPositive() {
+		return fmt.Errorf("price must be greater than 0")
+	}
+	if err := timeutils.ValidateWindows(p.Availability); err != nil {
+		return err
+	}
+
+	groupIDs := make(map[string]bool, len(p.ModifierGroups))
+	for i := range p.ModifierGroups {
+		group := &p.ModifierGroups[i]
+		if groupIDs[group.ID] {
+			return fmt.Errorf("duplicate modifier group id %q", group.ID)
+		}
+		groupIDs[group.ID] = true
+		if err := group.Validate(); err != nil {
+			return err
+		}
+		for _, option := range group.Options {
+			if option.PriceDelta.Currency != p.Price.Currency {
+				return fmt.Errorf("modifier %q must be priced in %s", option.Name, p.Price.Currency)
+			}
+			// The cheapest combination must still cost something
+			if p.Price.Add(option.PriceDelta).IsNegative() {
+				return fmt.Errorf("modifier %q discounts more than the product price", option.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// IsAvailableAt reports whether the product's own availability windows allow
+// ordering it at t, read in the restaurant's time zone loc.
+func (p *Product) IsAvailableAt(t time.Time, loc *time.Location) bool {
+	return timeutils.InAnyWindow(p.Availability, t, loc)
+}
+
+// IsDeleted reports whether the product has been soft deleted.
+func (p *Product) IsDeleted() bool {
+	return p.DeletedAt != nil
+}
+
+// Delete marks the product as removed. Past orders keep referencing it.
+func (p *Product) Delete(at time.Time) {
+	p.DeletedAt = &at
+	p.UpdatedAt = at
 }
@@ -0,0 +1,42 @@
+package product
+
+import (
+	"foodie/backend/pkg/money"
+	timeutils "foodie/backend/pkg/utils/time"
+)
+
+// SortField is an order products can be listed in.
+type SortField string
+
+const (
+	SortByName   SortField = "name"
+	SortByPrice  SortField = "price"
+	SortByNewest SortField = "newest" // Creation time; combine with SortDesc for newest first
+)
+
+// IsValid reports whether f is a known sort field.
+func (f SortField) IsValid() bool {
+	switch f {
+	case SortByName, SortByPrice, SortByNewest:
+		return true
+	}
+	return false
+}
+
+// Filter selects products for listing. Zero-valued fields do not filter.
+// Deleted products are never listed.
+type Filter struct {
+	RestaurantID string
+	CategoryID   string
+	Name         string // Case-insensitive substring match
+	MinPrice     *money.Money
+	MaxPrice     *money.Money
+	// AvailableAt keeps only products that can be ordered at this point in the
+	// restaurant's week, by their own and their category's availability windows.
+	AvailableAt *timeutils.Moment
+
+	SortBy   SortField // Default: name
+	SortDesc bool
+	Limit    int
+	Offset   int
+}
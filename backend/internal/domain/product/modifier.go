@@ -0,0 +1,139 @@
+package product
+
+import (
+	"fmt"
+	"strings"
+
+	"foodie/backend/pkg/money"
+)
+
+// ModifierGroup is a choice offered with a product, such as a size or toppings.
+type ModifierGroup struct {
+	ID            string
+	Name          string
+	Required      bool // At least MinSelections (and at least one) option must be chosen
+	MinSelections int
+	MaxSelections int // 0 allows choosing every option
+	Options       []ModifierOption
+}
+
+// ModifierOption is one choice within a group. PriceDelta is added to the
+// product's price for every unit ordered and may be negative (e.g. "small").
+type ModifierOption struct {
+	ID         string
+	Name       string
+	PriceDelta money.Money
+}
+
+// ModifierSelection is a customer's choice of an option within a group.
+type ModifierSelection struct {
+	GroupID  string
+	OptionID string
+}
+
+// SelectedModifier is a resolved selection, carrying the names and price
+// delta at the time of ordering.
+type SelectedModifier struct {
+	GroupID    string
+	GroupName  string
+	OptionID   string
+	OptionName string
+	PriceDelta money.Money
+}
+
+// Validate checks a modifier group's options and selection bounds.
+func (g *ModifierGroup) Validate() error {
+	if strings.TrimSpace(g.Name) == "" {
+		return fmt.Errorf("modifier group name is required")
+	}
+	if len(g.Options) == 0 {
+		return fmt.Errorf("modifier group %q must have at least one option", g.Name)
+	}
+	if g.MinSelections < 0 || g.MaxSelections < 0 {
+		return fmt.Errorf("modifier group %q: selection bounds must not be negative", g.Name)
+	}
+	if g.Required && g.MinSelections == 0 {
+		g.MinSelections = 1
+	}
+	if !g.Required && g.MinSelections > 0 {
+		return fmt.Errorf("modifier group %q: optional groups cannot have min_selections", g.Name)
+	}
+	if g.MaxSelections > len(g.Options) {
+		return fmt.Errorf("modifier group %q: max_selections exceeds the number of options", g.Name)
+	}
+	if g.MaxSelections > 0 && g.MinSelections > g.MaxSelections {
+		return fmt.Errorf("modifier group %q: min_selections exceeds max_selections", g.Name)
+	}
+	if g.MinSelections > len(g.Options) {
+		return fmt.Errorf("modifier group %q: min_selections exceeds the number of options", g.Name)
+	}
+
+	optionIDs := make(map[string]bool, len(g.Options))
+	for _, option := range g.Options {
+		if strings.TrimSpace(option.Name) == "" {
+			return fmt.Errorf("modifier group %q: option name is required", g.Name)
+		}
+		if optionIDs[option.ID] {
+			return fmt.Errorf("modifier group %q: duplicate option id %q", g.Name, option.ID)
+		}
+		optionIDs[option.ID] = true
+	}
+	return nil
+}
+
+// SelectModifiers checks a customer's selections against the product's
+// modifier groups and resolves them. Every required group must be satisfied,
+// no group may exceed its maximum, and an option may be chosen only once.
+func (p *Product) SelectModifiers(selections []ModifierSelection) ([]SelectedModifier, error) {
+	groups := make(map[string]*ModifierGroup, len(p.ModifierGroups))
+	for i := range p.ModifierGroups {
+		groups[p.ModifierGroups[i].ID] = &p.ModifierGroups[i]
+	}
+
+	counts := make(map[string]int, len(groups))
+	chosen := make(map[ModifierSelection]bool, len(selections))
+	selected := make([]SelectedModifier, 0, len(selections))
+	for _, s := range selections {
+		group, ok := groups[s.GroupID]
+		if !ok {
+			return nil, fmt.Errorf("product %s has no modifier group %q", p.ID, s.GroupID)
+		}
+		option, ok := group.option(s.OptionID)
+		if !ok {
+			return nil, fmt.Errorf("modifier group %q has no option %q", group.Name, s.OptionID)
+		}
+		if chosen[s] {
+			return nil, fmt.Errorf("option %q of %q chosen more than once", option.Name, group.Name)
+		}
+		chosen[s] = true
+		counts[group.ID]++
+
+		selected = append(selected, SelectedModifier{
+			GroupID:    group.ID,
+			GroupName:  group.Name,
+			OptionID:   option.ID,
+			OptionName: option.Name,
+			PriceDelta: option.PriceDelta,
+		})
+	}
+
+	for _, group := range p.ModifierGroups {
+		n := counts[group.ID]
+		if n < group.MinSelections {
+			return nil, fmt.Errorf("choose at least %d of %q", group.MinSelections, group.Name)
+		}
+		if group.MaxSelections > 0 && n > group.MaxSelections {
+			return nil, fmt.Errorf("choose at most %d of %q", group.MaxSelections, group.Name)
+		}
+	}
+	return selected, nil
+}
+
+func (g *ModifierGroup) option(id string) (ModifierOption, bool) {
+	for _, option := range g.Options {
+		if option.ID == id {
+			return option, true
+		}
+	}
+	return ModifierOption{}, false
+}
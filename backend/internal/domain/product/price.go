@@ -0,0 +1,49 @@
+package product
+
+import (
+	"fmt"
+	"time"
+
+	"foodie/backend/pkg/money"
+)
+
+// PriceChange is one entry of a product's price history: the product costs
+// Price from EffectiveFrom until the next change takes effect. Changes with
+// EffectiveFrom in the future are scheduled and do not apply yet.
+type PriceChange struct {
+	ID            string
+	ProductID     string
+	Price         money.Money
+	EffectiveFrom time.Time
+	CreatedBy     string // Empty for changes recorded by the system, e.g. imports
+	CreatedAt     time.Time
+	// AppliedAt is when the price was copied onto the product; nil while the
+	// change waits for the scheduler.
+	AppliedAt *time.Time
+}
+
+// Validate checks the price of a change.
+func (c *PriceChange) Validate() error {
+	if !c.Price.IsPositive() {
+		return fmt.Errorf("price must be greater than 0")
+	}
+	return nil
+}
+
+// IsScheduled reports whether the change has yet to take effect at now.
+func (c *PriceChange) IsScheduled(now time.Time) bool {
+	return c.EffectiveFrom.After(now)
+}
+
+// PriceAt returns the change in effect at t from a history sorted by
+// EffectiveFrom, or false if the product had no price yet.
+func PriceAt(history []PriceChange, t time.Time) (*PriceChange, bool) {
+	var current *PriceChange
+	for i := range history {
+		if history[i].EffectiveFrom.After(t) {
+			break
+		}
+		current = &history[i]
+	}
+	return current, current != nil
+}
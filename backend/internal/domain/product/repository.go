@@ -1,10 +1,48 @@
 package product
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Repository defines storage operations for products.
+// Soft-deleted products are invisible to every finder, and finders return the
+// price currently in effect by the product's price history.
 type Repository interface {
 	Save(ctx context.Context, product *Product) error
+	Update(ctx context.Context, product *Product) error
+	// FindByID returns ErrNotFound for unknown or deleted products.
 	FindByID(ctx context.Context, id string) (*Product, error)
 	FindByRestaurant(ctx context.Context, restaurantID string) ([]Product, error)
+	// FindByFilter returns one page of matching products and the total number of matches.
+	FindByFilter(ctx context.Context, filter Filter) ([]Product, int, error)
+	// Search returns one page of products matching query, most relevant first,
+	// and the total number of matches. Products in hidden categories are not found.
+	Search(ctx context.Context, query SearchQuery) ([]SearchResult, int, error)
+
+	// SetStock replaces a product's stock. Update never touches stock, so
+	// menu edits cannot overwrite concurrent reservations.
+	SetStock(ctx context.Context, productID string, stock Stock) error
+	// SetImage replaces a product's image and thumbnail URLs.
+	SetImage(ctx context.Context, productID, imageURL, thumbnailURL string) error
+	// ReserveStock atomically takes quantity units of a tracked product, or
+	// returns *OutOfStockError if fewer are left. Unlimited products always succeed.
+	ReserveStock(ctx context.Context, productID string, quantity int) error
+	// ReleaseStock returns quantity reserved units, e.g. when an order is cancelled.
+	// Daily products are never refilled above their quota.
+	ReleaseStock(ctx context.Context, productID string, quantity int) error
+	// ResetDailyStock refills every daily product to its quota and returns the
+	// products it changed.
+	ResetDailyStock(ctx context.Context) ([]Product, error)
+
+	// SavePriceChange records a price change. A change for the same product
+	// and effective time replaces the earlier one.
+	SavePriceChange(ctx context.Context, change *PriceChange) error
+	// FindPriceHistory returns a product's price changes, scheduled ones
+	// included, ordered by effective time.
+	FindPriceHistory(ctx context.Context, productID string) ([]PriceChange, error)
+	// ApplyDuePrices copies the price now in effect onto every product with a
+	// change that took effect by now and was not applied yet. It returns the
+	// updated products.
+	ApplyDuePrices(ctx context.Context, now time.Time) ([]Product, error)
 }
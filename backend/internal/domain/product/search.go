@@ -0,0 +1,25 @@
+package product
+
+import (
+	"foodie/backend/pkg/money"
+	timeutils "foodie/backend/pkg/utils/time"
+)
+
+// SearchQuery selects products by free text. Every word must match the start
+// of a word in the product name, so partial input works for autocomplete.
+// Zero-valued filters do not filter.
+type SearchQuery struct {
+	Text         string
+	RestaurantID string
+	MinPrice     *money.Money
+	MaxPrice     *money.Money
+	AvailableAt  *timeutils.Moment // See Filter.AvailableAt
+	Limit        int
+	Offset       int
+}
+
+// SearchResult is a product matching a search, with its relevance.
+type SearchResult struct {
+	Product Product
+	Rank    float64 // Higher is more relevant
+}
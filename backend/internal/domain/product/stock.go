@@ -0,0 +1,76 @@
+package product
+
+import "fmt"
+
+// StockMode is how a product's availability is tracked.
+type StockMode string
+
+const (
+	// StockUnlimited products never sell out.
+	StockUnlimited StockMode = "unlimited"
+	// StockDaily products may sell up to DailyQuota a day; the scheduler
+	// refills Available to the quota every night.
+	StockDaily StockMode = "daily"
+	// StockCount products sell until Available runs out and are restocked by hand.
+	StockCount StockMode = "count"
+)
+
+// IsValid reports whether m is a known stock mode.
+func (m StockMode) IsValid() bool {
+	switch m {
+	case StockUnlimited, StockDaily, StockCount:
+		return true
+	}
+	return false
+}
+
+// Stock is the availability of a product.
+type Stock struct {
+	Mode       StockMode
+	Available  int // Units left to sell; ignored for unlimited products
+	DailyQuota int // Units sold per day for daily products
+}
+
+// NewStock builds the stock for mode. quantity is the daily quota for daily
+// products and the units on hand for counted products; a new daily quota is
+// available immediately.
+func NewStock(mode StockMode, quantity int) (Stock, error) {
+	if mode == "" {
+		mode = StockUnlimited
+	}
+	if !mode.IsValid() {
+		return Stock{}, fmt.Errorf("unknown stock mode %q", mode)
+	}
+	if quantity < 0 {
+		return Stock{}, fmt.Errorf("stock quantity must not be negative")
+	}
+
+	switch mode {
+	case StockDaily:
+		return Stock{Mode: mode, Available: quantity, DailyQuota: quantity}, nil
+	case StockCount:
+		return Stock{Mode: mode, Available: quantity}, nil
+	default:
+		return Stock{Mode: StockUnlimited}, nil
+	}
+}
+
+// IsTracked reports whether orders draw down this stock.
+func (s Stock) IsTracked() bool {
+	return s.Mode != "" && s.Mode != StockUnlimited
+}
+
+// SoldOut reports whether no more units can be ordered.
+func (s Stock) SoldOut() bool {
+	return s.IsTracked() && s.Available <= 0
+}
+
+// OutOfStockError is returned when an order asks for more units than are left.
+type OutOfStockError struct {
+	ProductID string
+	Requested int
+}
+
+func (e *OutOfStockError) Error() string {
+	return fmt.Sprintf("product %s is out of stock (requested %d)", e.ProductID, e.Requested)
+}
@@ -0,0 +1,122 @@
+package restaurant
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	timeutils "foodie/backend/pkg/utils/time"
+)
+
+// Restaurant is a place customers order from. It owns its menu (products and
+// categories reference it by ID) and decides when orders are accepted.
+// Who may manage it is recorded separately; see OwnershipRepository.
+type Restaurant struct {
+	ID      string
+	Name    string
+	Address string
+	// Location is where couriers pick orders up; delivery distance is measured from it.
+	Location Location
+	// TimeZone is the IANA zone opening hours and menu availability windows are read in.
+	TimeZone string
+	Status   Status
+	// OpeningHours are the weekly windows orders are accepted in. Empty means always.
+	OpeningHours []timeutils.Window
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    *time.Time // Set when the restaurant is removed from the platform
+}
+
+// Location is a point on the map.
+type Location struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Status is whether a restaurant is taking orders.
+type Status string
+
+const (
+	StatusOpen   Status = "open"   // Taking orders within opening hours
+	StatusPaused Status = "paused" // Temporarily not taking orders, e.g. a kitchen at capacity
+	StatusClosed Status = "closed" // Not taking orders until reopened; left out of listings
+)
+
+// IsValid reports whether s is a known status.
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusOpen, StatusPaused, StatusClosed:
+		return true
+	}
+	return false
+}
+
+// ErrNotFound is returned when a restaurant does not exist or has been deleted.
+var ErrNotFound = errors.New("restaurant not found")
+
+// Lengths match the restaurants columns.
+const (
+	maxNameLength    = 255
+	maxAddressLength = 500
+)
+
+// Validate checks the fields an owner can edit.
+func (r *Restaurant) Validate() error {
+	name := strings.TrimSpace(r.Name)
+	if name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(name) > maxNameLength {
+		return fmt.Errorf("name must be at most %d characters", maxNameLength)
+	}
+	address := strings.TrimSpace(r.Address)
+	if address == "" {
+		return fmt.Errorf("address is required")
+	}
+	if len(address) > maxAddressLength {
+		return fmt.Errorf("address must be at most %d characters", maxAddressLength)
+	}
+	if r.Location.Latitude < -90 || r.Location.Latitude > 90 {
+		return fmt.Errorf("latitude must be between -90 and 90")
+	}
+	if r.Location.Longitude < -180 || r.Location.Longitude > 180 {
+		return fmt.Errorf("longitude must be between -180 and 180")
+	}
+	if r.TimeZone == "" {
+		return fmt.Errorf("time_zone is required")
+	}
+	if _, err := time.LoadLocation(r.TimeZone); err != nil {
+		return fmt.Errorf("unknown time zone %q", r.TimeZone)
+	}
+	if !r.Status.IsValid() {
+		return fmt.Errorf("invalid status %q", r.Status)
+	}
+	for i, w := range r.OpeningHours {
+		if err := w.Validate(); err != nil {
+			return fmt.Errorf("opening_hours[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Zone returns the restaurant's time zone, or UTC if it cannot be loaded.
+func (r *Restaurant) Zone() *time.Location {
+	loc, err := time.LoadLocation(r.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// IsOpenAt reports whether the restaurant takes orders at t: it must be open
+// and t must fall within its opening hours.
+func (r *Restaurant) IsOpenAt(t time.Time) bool {
+	return r.Status == StatusOpen && timeutils.InAnyWindow(r.OpeningHours, t, r.Zone())
+}
+
+// Delete marks the restaurant as removed. Past orders keep referencing it.
+func (r *Restaurant) Delete(at time.Time) {
+	r.DeletedAt = &at
+	r.UpdatedAt = at
+}
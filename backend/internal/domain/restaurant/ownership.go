@@ -0,0 +1,34 @@
+package restaurant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// OwnershipRepository answers who may act on behalf of a restaurant.
+type OwnershipRepository interface {
+	// IsOwner reports whether userID owns (or manages) restaurantID.
+	IsOwner(ctx context.Context, restaurantID, userID string) (bool, error)
+}
+
+// ErrNotOwner is returned when a restaurant account acts on a restaurant it does not own.
+var ErrNotOwner = errors.New("user does not own this restaurant")
+
+// Authorize checks that a user acting with role may manage restaurantID.
+// Admins may act for any restaurant; restaurant accounts only for those they own.
+func Authorize(ctx context.Context, owners OwnershipRepository, restaurantID, userID, role string) error {
+	switch role {
+	case "admin":
+		return nil
+	case "restaurant":
+		owns, err := owners.IsOwner(ctx, restaurantID, userID)
+		if err != nil {
+			return fmt.Errorf("failed to check restaurant ownership: %w", err)
+		}
+		if owns {
+			return nil
+		}
+	}
+	return ErrNotOwner
+}
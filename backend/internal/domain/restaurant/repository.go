@@ -0,0 +1,33 @@
+package restaurant
+
+import "context"
+
+// Repository defines storage operations for restaurants.
+// Soft-deleted restaurants are invisible to every finder.
+type Repository interface {
+	// Save inserts a restaurant and lists ownerID among its owners, which is
+	// where OwnershipRepository looks.
+	Save(ctx context.Context, restaurant *Restaurant, ownerID string) error
+	// Update writes the editable fields of a restaurant, including its
+	// soft-delete marker. Returns ErrNotFound for unknown or deleted restaurants.
+	Update(ctx context.Context, restaurant *Restaurant) error
+	// FindByID returns ErrNotFound for unknown or deleted restaurants.
+	FindByID(ctx context.Context, id string) (*Restaurant, error)
+	// FindByFilter returns one page of matching restaurants, by name, and the
+	// total number of matches.
+	FindByFilter(ctx context.Context, filter Filter) ([]Restaurant, int, error)
+	// Search returns one page of restaurants matching a full-text query, most
+	// relevant first, and the total number of matches.
+	Search(ctx context.Context, query SearchQuery) ([]SearchResult, int, error)
+}
+
+// Filter selects restaurants for listing. Zero-valued fields do not filter.
+// Deleted restaurants are never listed.
+type Filter struct {
+	Name     string   // Case-insensitive substring match
+	Statuses []Status // Any of these
+	OwnerID  string   // Restaurants this user is listed as an owner of
+
+	Limit  int
+	Offset int
+}
@@ -0,0 +1,17 @@
+package restaurant
+
+// SearchQuery selects restaurants by free text. Every word must match the
+// start of a word in the restaurant's name or address, so partial input works
+// for autocomplete. Zero-valued filters do not filter.
+type SearchQuery struct {
+	Text     string
+	Statuses []Status // Any of these
+	Limit    int
+	Offset   int
+}
+
+// SearchResult is a restaurant matching a search, with its relevance.
+type SearchResult struct {
+	Restaurant Restaurant
+	Rank       float64 // Higher is more relevant
+}
@@ -0,0 +1,80 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"foodie/backend/pkg/utils/validation"
+)
+
+// User is an account that can sign in: a customer, a restaurant account or an admin.
+type User struct {
+	ID    string
+	Email string // Stored normalized, see NormalizeEmail
+	Name  string
+	Role  Role
+	// PasswordHash is the encoded hash of the password, see HashPassword.
+	PasswordHash string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Role decides what an account may do. The values are the roles carried in
+// access tokens and checked by the HTTP middleware.
+type Role string
+
+const (
+	RoleCustomer   Role = "user"       // Places orders
+	RoleRestaurant Role = "restaurant" // Manages the restaurants it owns
+	RoleAdmin      Role = "admin"      // Manages everything; never self-registered
+)
+
+// IsValid reports whether r is a known role.
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleCustomer, RoleRestaurant, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+var (
+	// ErrNotFound is returned when a user does not exist.
+	ErrNotFound = errors.New("user not found")
+	// ErrEmailTaken is returned when registering an email that already has an account.
+	ErrEmailTaken = errors.New("email is already registered")
+)
+
+// Lengths match the users columns.
+const (
+	maxEmailLength = 255
+	maxNameLength  = 255
+)
+
+// NormalizeEmail trims and lower-cases an email so each address has one account.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// Validate checks the fields a user provides.
+func (u *User) Validate() error {
+	if !validation.IsValidEmail(u.Email) || strings.ContainsAny(u.Email, " \t\r\n") {
+		return fmt.Errorf("email is invalid")
+	}
+	if len(u.Email) > maxEmailLength {
+		return fmt.Errorf("email must be at most %d characters", maxEmailLength)
+	}
+	name := strings.TrimSpace(u.Name)
+	if name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(name) > maxNameLength {
+		return fmt.Errorf("name must be at most %d characters", maxNameLength)
+	}
+	if !u.Role.IsValid() {
+		return fmt.Errorf("invalid role %q", u.Role)
+	}
+	return nil
+}
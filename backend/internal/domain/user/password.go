@@ -0,0 +1,95 @@
+package user
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Password length limits. The maximum bounds the work a login request can cause.
+const (
+	MinPasswordLength = 8
+	MaxPasswordLength = 128
+)
+
+// Argon2id parameters for new passwords (the OWASP minimum of 19 MiB, two
+// passes). Stored hashes carry their own, so the cost can be raised without
+// invalidating existing passwords.
+const (
+	argon2Scheme    = "argon2id"
+	argon2Memory    = 19 * 1024 // KiB
+	argon2Time      = 2
+	argon2Threads   = 1
+	argon2SaltBytes = 16
+	argon2KeyBytes  = 32
+)
+
+// ValidatePassword checks a new password's length.
+func ValidatePassword(password string) error {
+	n := utf8.RuneCountInString(password)
+	if n < MinPasswordLength {
+		return fmt.Errorf("password must be at least %d characters", MinPasswordLength)
+	}
+	if n > MaxPasswordLength {
+		return fmt.Errorf("password must be at most %d characters", MaxPasswordLength)
+	}
+	return nil
+}
+
+// HashPassword derives a salted Argon2id hash of password in the PHC string
+// format, "$argon2id$v=19$m=<KiB>,t=<passes>,p=<threads>$<salt>$<key>". The
+// leading scheme lets hashes be moved to another algorithm later.
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, argon2SaltBytes)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+	key := argon2.IDKey([]byte(password), salt, argon2Time, argon2Memory, argon2Threads, argon2KeyBytes)
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2Scheme, argon2.Version,
+		argon2Memory, argon2Time, argon2Threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// CheckPassword reports whether password matches the user's password hash.
+func (u *User) CheckPassword(password string) bool {
+	return CheckPasswordHash(u.PasswordHash, password)
+}
+
+// CheckPasswordHash reports whether password matches an encoded hash from
+// HashPassword. Malformed hashes and unknown schemes never match.
+func CheckPasswordHash(encoded, password string) bool {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != argon2Scheme {
+		return false
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false
+	}
+	var memory, passes uint32
+	var threads uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &passes, &threads); err != nil {
+		return false
+	}
+	// Refuse parameters no hash from HashPassword has, so a tampered row
+	// cannot make logins arbitrarily expensive
+	if passes < 1 || threads < 1 || memory > 1<<20 || passes > 16 {
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false
+	}
+	want, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(want) == 0 {
+		return false
+	}
+
+	got := argon2.IDKey([]byte(password), salt, passes, memory, threads, uint32(len(want)))
+	return subtle.ConstantTimeCompare(got, want) == 1
+}
@@ -0,0 +1,13 @@
+package user
+
+import "context"
+
+// Repository defines storage operations for user accounts.
+type Repository interface {
+	// Save inserts a user. Returns ErrEmailTaken if the email already has an account.
+	Save(ctx context.Context, user *User) error
+	// FindByID returns ErrNotFound for unknown users.
+	FindByID(ctx context.Context, id string) (*User, error)
+	// FindByEmail looks a user up by normalized email. Returns ErrNotFound for unknown emails.
+	FindByEmail(ctx context.Context, email string) (*User, error)
+}
@@ -0,0 +1,141 @@
+package category
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"foodie/backend/internal/domain/category"
+	"foodie/backend/internal/infrastructure/database/txn"
+	timeutils "foodie/backend/pkg/utils/time"
+)
+
+// Repository implements category.Repository using SQL.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository creates a new SQL-based category repository.
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+const categoryColumns = `id, restaurant_id, name, sort_order, visible, availability, created_at, updated_at`
+
+// Save inserts a new category row.
+func (r *Repository) Save(ctx context.Context, c *category.Category) error {
+	availability, slots, err := marshalAvailability(c.Availability)
+	if err != nil {
+		return err
+	}
+
+	const query = `INSERT INTO categories (` + categoryColumns + `, availability_slots) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err = txn.Executor(ctx, r.db).ExecContext(ctx, query,
+		c.ID, c.RestaurantID, c.Name, c.SortOrder, c.Visible, availability, c.CreatedAt, c.UpdatedAt, slots,
+	)
+	return err
+}
+
+// Update writes the editable fields of a category.
+func (r *Repository) Update(ctx context.Context, c *category.Category) error {
+	availability, slots, err := marshalAvailability(c.Availability)
+	if err != nil {
+		return err
+	}
+
+	const query = `
+		UPDATE categories
+		SET name = $2, sort_order = $3, visible = $4, availability = $5, availability_slots = $6, updated_at = $7
+		WHERE id = $1
+	`
+	result, err := txn.Executor(ctx, r.db).ExecContext(ctx, query,
+		c.ID, c.Name, c.SortOrder, c.Visible, availability, slots, c.UpdatedAt,
+	)
+	return requireRow(result, err)
+}
+
+// marshalAvailability serializes availability windows for the availability
+// column, and their slots for the availability_slots column product listings
+// filter on.
+func marshalAvailability(windows []timeutils.Window) (availability, slots []byte, err error) {
+	if windows == nil {
+		windows = []timeutils.Window{}
+	}
+	if availability, err = json.Marshal(windows); err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal availability: %w", err)
+	}
+	if slots, err = json.Marshal(timeutils.SlotsOf(windows)); err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal availability slots: %w", err)
+	}
+	return availability, slots, nil
+}
+
+// Delete removes a category. The foreign key uncategorizes its products.
+func (r *Repository) Delete(ctx context.Context, id string) error {
+	result, err := txn.Executor(ctx, r.db).ExecContext(ctx, `DELETE FROM categories WHERE id = $1`, id)
+	return requireRow(result, err)
+}
+
+// FindByID loads a category by its ID.
+func (r *Repository) FindByID(ctx context.Context, id string) (*category.Category, error) {
+	const query = `SELECT ` + categoryColumns + ` FROM categories WHERE id = $1`
+	c, err := scanCategory(txn.Executor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, category.ErrNotFound
+	}
+	return c, err
+}
+
+// FindByRestaurant loads a restaurant's categories in menu order.
+func (r *Repository) FindByRestaurant(ctx context.Context, restaurantID string) ([]category.Category, error) {
+	const query = `SELECT ` + categoryColumns + ` FROM categories WHERE restaurant_id = $1 ORDER BY sort_order, name, id`
+	rows, err := txn.Executor(ctx, r.db).QueryContext(ctx, query, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []category.Category
+	for rows.Next() {
+		c, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, *c)
+	}
+	return categories, rows.Err()
+}
+
+// requireRow maps a statement that matched no category to category.ErrNotFound.
+func requireRow(result sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return category.ErrNotFound
+	}
+	return nil
+}
+
+// scanner is satisfied by both *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanCategory(s scanner) (*category.Category, error) {
+	var c category.Category
+	var availability []byte
+	if err := s.Scan(&c.ID, &c.RestaurantID, &c.Name, &c.SortOrder, &c.Visible, &availability, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(availability, &c.Availability); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal availability: %w", err)
+	}
+	return &c, nil
+}
@@ -0,0 +1,22 @@
+// Package fulltext builds PostgreSQL full-text queries shared by the
+// searchable tables, so products and restaurants match input the same way.
+package fulltext
+
+import (
+	"strings"
+	"unicode"
+)
+
+// PrefixQuery turns free text into a tsquery where every word is a prefix
+// match, e.g. "chick sal" becomes "chick:* & sal:*". Anything but letters and
+// digits separates words, so tsquery operators in the input are never
+// interpreted. It returns "" when text has no words.
+func PrefixQuery(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r)
+	})
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & ")
+}
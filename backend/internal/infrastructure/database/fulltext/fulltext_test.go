@@ -0,0 +1,24 @@
+package fulltext
+
+import "testing"
+
+func TestPrefixQuery(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"chick sal", "chick:* & sal:*"},
+		{"  Phở  Bò ", "phở:* & bò:*"},
+		{"Pho 24", "pho:* & 24:*"},
+		// tsquery operators are separators, never syntax
+		{"a & !b | c:*", "a:* & b:* & c:*"},
+		{"o'brien's", "o:* & brien:* & s:*"},
+		{"", ""},
+		{"&|!", ""},
+	}
+	for _, tt := range tests {
+		if got := PrefixQuery(tt.text); got != tt.want {
+			t.Errorf("PrefixQuery(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
@@ -5,12 +5,15 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
-	"time"
+	"strings"
 
 	"foodie/backend/internal/domain/order"
+	"foodie/backend/internal/infrastructure/database/txn"
+	"foodie/backend/pkg/money"
 )
 
 // Repository is a Postgres/MySQL-style implementation of order.Repository.
+// Queries join the caller's transaction when one is bound to the context.
 type Repository struct {
 	db *sql.DB
 }
@@ -20,6 +23,15 @@ func NewRepository(db *sql.DB) *Repository {
 	return &Repository{db: db}
 }
 
+// orderColumns is the column list shared by every SELECT on the orders table.
+// Keep it in sync with scanOrder.
+const orderColumns = `id, user_id, restaurant_id, status, items,
+		subtotal, discount, service_fee, tax, total, currency, promo_code,
+		payment_method, payment_id, payment_status, delivery_address,
+		delivery_fee, distance_km, estimated_delivery_at, prep_time_minutes,
+		cancelled_by, cancelled_by_role, cancellation_reason, cancellation_note, cancelled_at,
+		created_at, updated_at`
+
 // Save inserts a new order row.
 func (r *Repository) Save(ctx context.Context, o *order.Order) error {
 	// Serialize items to JSON
@@ -29,64 +41,83 @@ func (r *Repository) Save(ctx context.Context, o *order.Order) error {
 	}
 
 	const query = `INSERT INTO orders (
-		id, user_id, restaurant_id, status, items, total, 
-		payment_method, delivery_address, created_at, updated_at
-	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+		id, user_id, restaurant_id, status, items,
+		subtotal, discount, service_fee, tax, total, currency, promo_code,
+		payment_method, payment_id, payment_status, delivery_address,
+		delivery_fee, distance_km, estimated_delivery_at, created_at, updated_at
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)`
 
-	_, err = r.db.ExecContext(ctx, query,
+	_, err = txn.Executor(ctx, r.db).ExecContext(ctx, query,
 		o.ID, o.UserID, o.RestaurantID, string(o.Status),
-		itemsJSON, o.Total, o.PaymentMethod, o.DeliveryAddress,
-		o.CreatedAt, o.UpdatedAt,
+		itemsJSON, o.Subtotal.Decimal(), o.Discount.Decimal(), o.ServiceFee.Decimal(), o.Tax.Decimal(),
+		o.Total.Decimal(), o.Currency(), nullString(o.PromoCode),
+		o.PaymentMethod, nullString(o.PaymentID), string(o.PaymentStatus),
+		o.DeliveryAddress, o.DeliveryFee.Decimal(), o.DistanceKm, o.EstimatedDeliveryAt, o.CreatedAt, o.UpdatedAt,
 	)
 	return err
 }
 
-// FindByID loads an order by its ID.
-func (r *Repository) FindByID(ctx context.Context, id string) (*order.Order, error) {
-	const query = `SELECT 
-		id, user_id, restaurant_id, status, items, total,
-		payment_method, delivery_address, created_at, updated_at
-		FROM orders WHERE id = $1`
-
-	row := r.db.QueryRowContext(ctx, query, id)
+// Update persists changes to an existing order's mutable fields, provided
+// no other request changed its status since it was read.
+func (r *Repository) Update(ctx context.Context, o *order.Order, previous order.OrderStatus) error {
+	const query = `UPDATE orders SET
+		status = $2, payment_id = $3, payment_status = $4,
+		cancelled_by = $5, cancelled_by_role = $6, cancellation_reason = $7,
+		cancellation_note = $8, cancelled_at = $9, estimated_delivery_at = $10,
+		prep_time_minutes = $11, updated_at = $12
+		WHERE id = $1 AND status = $13`
 
-	var o order.Order
-	var statusStr string
-	var itemsJSON []byte
-	var createdAt, updatedAt time.Time
+	var cancelledBy, cancelledByRole, reason, note sql.NullString
+	var cancelledAt sql.NullTime
+	if c := o.Cancellation; c != nil {
+		cancelledBy = nullString(c.By)
+		cancelledByRole = nullString(string(c.Role))
+		reason = nullString(string(c.Reason))
+		note = nullString(c.Note)
+		cancelledAt = sql.NullTime{Time: c.At, Valid: true}
+	}
 
-	err := row.Scan(
-		&o.ID, &o.UserID, &o.RestaurantID, &statusStr,
-		&itemsJSON, &o.Total, &o.PaymentMethod, &o.DeliveryAddress,
-		&createdAt, &updatedAt,
+	result, err := txn.Executor(ctx, r.db).ExecContext(ctx, query,
+		o.ID, string(o.Status), nullString(o.PaymentID), string(o.PaymentStatus),
+		cancelledBy, cancelledByRole, reason, note, cancelledAt, o.EstimatedDeliveryAt,
+		sql.NullInt64{Int64: int64(o.PrepTimeMinutes), Valid: o.PrepTimeMinutes > 0}, o.UpdatedAt,
+		string(previous),
 	)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	o.Status = order.OrderStatus(statusStr)
-	o.CreatedAt = createdAt
-	o.UpdatedAt = updatedAt
-
-	// Deserialize items
-	if err := json.Unmarshal(itemsJSON, &o.Items); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal items: %w", err)
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
 	}
+	// The order was read by the caller, so a miss means its status moved on
+	if affected == 0 {
+		return order.ErrStatusChanged
+	}
+	return nil
+}
 
-	return &o, nil
+// FindByID loads an order by its ID.
+func (r *Repository) FindByID(ctx context.Context, id string) (*order.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE id = $1`
+
+	o, err := scanOrder(txn.Executor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		return nil, err
+	}
+	return o, nil
 }
 
 // FindByUserID loads orders by user ID with pagination.
 func (r *Repository) FindByUserID(ctx context.Context, userID string, limit, offset int) ([]order.Order, error) {
-	const query = `SELECT 
-		id, user_id, restaurant_id, status, items, total,
-		payment_method, delivery_address, created_at, updated_at
-		FROM orders 
-		WHERE user_id = $1 
-		ORDER BY created_at DESC 
+	query := `SELECT ` + orderColumns + `
+		FROM orders
+		WHERE user_id = $1
+		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3`
 
-	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	rows, err := txn.Executor(ctx, r.db).QueryContext(ctx, query, userID, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -94,28 +125,11 @@ func (r *Repository) FindByUserID(ctx context.Context, userID string, limit, off
 
 	var orders []order.Order
 	for rows.Next() {
-		var o order.Order
-		var statusStr string
-		var itemsJSON []byte
-		var createdAt, updatedAt time.Time
-
-		if err := rows.Scan(
-			&o.ID, &o.UserID, &o.RestaurantID, &statusStr,
-			&itemsJSON, &o.Total, &o.PaymentMethod, &o.DeliveryAddress,
-			&createdAt, &updatedAt,
-		); err != nil {
+		o, err := scanOrder(rows)
+		if err != nil {
 			return nil, err
 		}
-
-		o.Status = order.OrderStatus(statusStr)
-		o.CreatedAt = createdAt
-		o.UpdatedAt = updatedAt
-
-		if err := json.Unmarshal(itemsJSON, &o.Items); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal items: %w", err)
-		}
-
-		orders = append(orders, o)
+		orders = append(orders, *o)
 	}
 
 	return orders, rows.Err()
@@ -125,6 +139,221 @@ func (r *Repository) FindByUserID(ctx context.Context, userID string, limit, off
 func (r *Repository) CountByUserID(ctx context.Context, userID string) (int, error) {
 	const query = `SELECT COUNT(*) FROM orders WHERE user_id = $1`
 	var count int
-	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	err := txn.Executor(ctx, r.db).QueryRowContext(ctx, query, userID).Scan(&count)
 	return count, err
 }
+
+// FindByFilter returns one page of orders matching filter, filtered and sorted
+// in SQL, along with the total number of matching orders.
+func (r *Repository) FindByFilter(ctx context.Context, filter order.Filter) ([]order.Order, int, error) {
+	where, args := filterConditions(filter)
+	exec := txn.Executor(ctx, r.db)
+
+	var total int
+	if err := exec.QueryRowContext(ctx, `SELECT COUNT(*) FROM orders`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	sortBy := filter.SortBy
+	if !sortBy.IsValid() {
+		sortBy = order.SortByCreatedAt
+	}
+	direction := "ASC"
+	if filter.SortDesc {
+		direction = "DESC"
+	}
+
+	// sortBy is whitelisted above, so it is safe to interpolate
+	query := `SELECT ` + orderColumns + ` FROM orders` + where +
+		fmt.Sprintf(` ORDER BY %s %s, id %s LIMIT $%d OFFSET $%d`, sortBy, direction, direction, len(args)+1, len(args)+2)
+	rows, err := exec.QueryContext(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var orders []order.Order
+	for rows.Next() {
+		o, err := scanOrder(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		orders = append(orders, *o)
+	}
+
+	return orders, total, rows.Err()
+}
+
+// filterConditions builds the WHERE clause and its arguments for filter.
+func filterConditions(filter order.Filter) (string, []any) {
+	var conditions []string
+	var args []any
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.UserID != "" {
+		add("user_id = $%d", filter.UserID)
+	}
+	if filter.RestaurantID != "" {
+		add("restaurant_id = $%d", filter.RestaurantID)
+	}
+	if len(filter.Statuses) > 0 {
+		placeholders := make([]string, 0, len(filter.Statuses))
+		for _, status := range filter.Statuses {
+			args = append(args, string(status))
+			placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+		}
+		conditions = append(conditions, "status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if filter.CreatedFrom != nil {
+		add("created_at >= $%d", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		add("created_at < $%d", *filter.CreatedTo)
+	}
+	if filter.PaymentMethod != "" {
+		add("payment_method = $%d", filter.PaymentMethod)
+	}
+	if filter.MinTotal != nil {
+		add("currency = $%d", filter.MinTotal.Currency)
+		add("total >= $%d", filter.MinTotal.Decimal())
+	}
+	if filter.MaxTotal != nil {
+		add("currency = $%d", filter.MaxTotal.Currency)
+		add("total <= $%d", filter.MaxTotal.Decimal())
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// SaveStatusChange appends an entry to the order's status timeline.
+func (r *Repository) SaveStatusChange(ctx context.Context, change *order.StatusChange) error {
+	const query = `INSERT INTO order_status_history (
+		order_id, from_status, to_status, actor_id, actor_role, note, created_at
+	) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+
+	return txn.Executor(ctx, r.db).QueryRowContext(ctx, query,
+		change.OrderID, nullString(string(change.FromStatus)), string(change.ToStatus),
+		nullString(change.ActorID), nullString(string(change.ActorRole)), nullString(change.Note),
+		change.CreatedAt,
+	).Scan(&change.ID)
+}
+
+// FindStatusHistory returns an order's status timeline, oldest first.
+func (r *Repository) FindStatusHistory(ctx context.Context, orderID string) ([]order.StatusChange, error) {
+	const query = `SELECT id, order_id, from_status, to_status, actor_id, actor_role, note, created_at
+		FROM order_status_history
+		WHERE order_id = $1
+		ORDER BY created_at, id`
+
+	rows, err := txn.Executor(ctx, r.db).QueryContext(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []order.StatusChange
+	for rows.Next() {
+		var c order.StatusChange
+		var toStatus string
+		var fromStatus, actorID, actorRole, note sql.NullString
+		if err := rows.Scan(&c.ID, &c.OrderID, &fromStatus, &toStatus, &actorID, &actorRole, &note, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		c.FromStatus = order.OrderStatus(fromStatus.String)
+		c.ToStatus = order.OrderStatus(toStatus)
+		c.ActorID = actorID.String
+		c.ActorRole = order.ActorRole(actorRole.String)
+		c.Note = note.String
+		history = append(history, c)
+	}
+
+	return history, rows.Err()
+}
+
+// scanner is satisfied by both *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanOrder reads one row selected with orderColumns into a domain Order.
+func scanOrder(s scanner) (*order.Order, error) {
+	var o order.Order
+	var statusStr, paymentStatus, currency string
+	var subtotal, discount, serviceFee, tax, total, deliveryFee string
+	var itemsJSON []byte
+	var paymentID, promoCode sql.NullString
+	var cancelledBy, cancelledByRole, reason, note sql.NullString
+	var cancelledAt, estimatedDeliveryAt sql.NullTime
+	var distanceKm sql.NullFloat64
+	var prepTimeMinutes sql.NullInt64
+
+	err := s.Scan(
+		&o.ID, &o.UserID, &o.RestaurantID, &statusStr,
+		&itemsJSON, &subtotal, &discount, &serviceFee, &tax, &total, &currency, &promoCode,
+		&o.PaymentMethod, &paymentID, &paymentStatus, &o.DeliveryAddress,
+		&deliveryFee, &distanceKm, &estimatedDeliveryAt, &prepTimeMinutes,
+		&cancelledBy, &cancelledByRole, &reason, &note, &cancelledAt,
+		&o.CreatedAt, &o.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	o.Status = order.OrderStatus(statusStr)
+	o.PaymentID = paymentID.String
+	o.PromoCode = promoCode.String
+	o.PaymentStatus = order.PaymentStatus(paymentStatus)
+	o.DistanceKm = distanceKm.Float64
+	o.PrepTimeMinutes = int(prepTimeMinutes.Int64)
+	if estimatedDeliveryAt.Valid {
+		o.EstimatedDeliveryAt = &estimatedDeliveryAt.Time
+	}
+
+	if cancelledAt.Valid {
+		o.Cancellation = &order.Cancellation{
+			By:     cancelledBy.String,
+			Role:   order.ActorRole(cancelledByRole.String),
+			Reason: order.CancellationReason(reason.String),
+			Note:   note.String,
+			At:     cancelledAt.Time,
+		}
+	}
+
+	amounts := []struct {
+		dst   *money.Money
+		value string
+	}{
+		{&o.Subtotal, subtotal}, {&o.Discount, discount}, {&o.ServiceFee, serviceFee},
+		{&o.Tax, tax}, {&o.Total, total}, {&o.DeliveryFee, deliveryFee},
+	}
+	for _, a := range amounts {
+		if *a.dst, err = money.Parse(a.value, currency); err != nil {
+			return nil, fmt.Errorf("failed to parse order amount: %w", err)
+		}
+	}
+
+	// Deserialize items; prices stored before the currency column existed carry no currency
+	if err := json.Unmarshal(itemsJSON, &o.Items); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal items: %w", err)
+	}
+	for i := range o.Items {
+		item := &o.Items[i]
+		item.Price = item.Price.WithCurrency(currency)
+		for j := range item.Modifiers {
+			item.Modifiers[j].PriceDelta = item.Modifiers[j].PriceDelta.WithCurrency(currency)
+		}
+	}
+
+	return &o, nil
+}
+
+// nullString maps an empty string to SQL NULL.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,127 @@
+package payment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"foodie/backend/internal/domain/payment"
+	"foodie/backend/internal/infrastructure/database/txn"
+	"foodie/backend/pkg/money"
+)
+
+// Repository is a Postgres implementation of payment.Repository.
+// Queries join the caller's transaction when one is bound to the context.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository creates a new SQL-based payment repository.
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// paymentColumns is the column list shared by every SELECT on the payments table.
+// Keep it in sync with scanPayment.
+const paymentColumns = `id, order_id, method, amount, currency, status,
+		gateway_payment_id, gateway_transaction_id, failure_reason,
+		authorized_at, captured_at, refunded_at, created_at, updated_at`
+
+// Save inserts a new payment row.
+func (r *Repository) Save(ctx context.Context, p *payment.Payment) error {
+	const query = `INSERT INTO payments (` + paymentColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+
+	_, err := txn.Executor(ctx, r.db).ExecContext(ctx, query,
+		p.ID, p.OrderID, p.Method, p.Amount.Decimal(), p.Amount.Currency, string(p.Status),
+		nullString(p.GatewayPaymentID), nullString(p.GatewayTransactionID), nullString(p.FailureReason),
+		p.AuthorizedAt, p.CapturedAt, p.RefundedAt, p.CreatedAt, p.UpdatedAt,
+	)
+	return err
+}
+
+// Update persists changes to an existing payment's mutable fields.
+func (r *Repository) Update(ctx context.Context, p *payment.Payment) error {
+	const query = `UPDATE payments SET
+		status = $2, gateway_payment_id = $3, gateway_transaction_id = $4, failure_reason = $5,
+		authorized_at = $6, captured_at = $7, refunded_at = $8, updated_at = $9
+		WHERE id = $1`
+
+	result, err := txn.Executor(ctx, r.db).ExecContext(ctx, query,
+		p.ID, string(p.Status),
+		nullString(p.GatewayPaymentID), nullString(p.GatewayTransactionID), nullString(p.FailureReason),
+		p.AuthorizedAt, p.CapturedAt, p.RefundedAt, p.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return payment.ErrNotFound
+	}
+	return nil
+}
+
+// FindByID loads a payment by its ID.
+func (r *Repository) FindByID(ctx context.Context, id string) (*payment.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE id = $1`
+	return scanPayment(txn.Executor(ctx, r.db).QueryRowContext(ctx, query, id))
+}
+
+// FindByOrderID loads the payment attached to an order.
+func (r *Repository) FindByOrderID(ctx context.Context, orderID string) (*payment.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE order_id = $1`
+	return scanPayment(txn.Executor(ctx, r.db).QueryRowContext(ctx, query, orderID))
+}
+
+// scanPayment reads one row selected with paymentColumns into a domain Payment.
+func scanPayment(row *sql.Row) (*payment.Payment, error) {
+	var p payment.Payment
+	var status, amount, currency string
+	var gatewayPaymentID, gatewayTransactionID, failureReason sql.NullString
+	var authorizedAt, capturedAt, refundedAt sql.NullTime
+
+	err := row.Scan(
+		&p.ID, &p.OrderID, &p.Method, &amount, &currency, &status,
+		&gatewayPaymentID, &gatewayTransactionID, &failureReason,
+		&authorizedAt, &capturedAt, &refundedAt, &p.CreatedAt, &p.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, payment.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Amount, err = money.Parse(amount, currency); err != nil {
+		return nil, fmt.Errorf("failed to parse payment amount: %w", err)
+	}
+	p.Status = payment.Status(status)
+	p.GatewayPaymentID = gatewayPaymentID.String
+	p.GatewayTransactionID = gatewayTransactionID.String
+	p.FailureReason = failureReason.String
+	p.AuthorizedAt = nullTime(authorizedAt)
+	p.CapturedAt = nullTime(capturedAt)
+	p.RefundedAt = nullTime(refundedAt)
+
+	return &p, nil
+}
+
+// nullString maps an empty string to SQL NULL.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// nullTime maps SQL NULL to a nil *time.Time.
+func nullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
@@ -0,0 +1,101 @@
+package product
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"foodie/backend/internal/domain/product"
+	"foodie/backend/internal/infrastructure/database/txn"
+	"foodie/backend/pkg/money"
+)
+
+// SavePriceChange inserts a price change, replacing one scheduled for the
+// same product and time.
+func (r *Repository) SavePriceChange(ctx context.Context, c *product.PriceChange) error {
+	const query = `
+		INSERT INTO product_prices (id, product_id, price, currency, effective_from, created_by, created_at, applied_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (product_id, effective_from) DO UPDATE
+		SET price = EXCLUDED.price, currency = EXCLUDED.currency, created_by = EXCLUDED.created_by,
+			created_at = EXCLUDED.created_at, applied_at = EXCLUDED.applied_at
+		RETURNING id
+	`
+	return txn.Executor(ctx, r.db).QueryRowContext(ctx, query,
+		c.ID, c.ProductID, c.Price.Decimal(), c.Price.Currency, c.EffectiveFrom,
+		nullString(c.CreatedBy), c.CreatedAt, c.AppliedAt,
+	).Scan(&c.ID)
+}
+
+// FindPriceHistory loads a product's price changes, oldest first.
+func (r *Repository) FindPriceHistory(ctx context.Context, productID string) ([]product.PriceChange, error) {
+	const query = `
+		SELECT id, product_id, price, currency, effective_from, created_by, created_at, applied_at
+		FROM product_prices
+		WHERE product_id = $1
+		ORDER BY effective_from
+	`
+	rows, err := txn.Executor(ctx, r.db).QueryContext(ctx, query, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []product.PriceChange
+	for rows.Next() {
+		var c product.PriceChange
+		var price, currency string
+		var createdBy sql.NullString
+		var appliedAt sql.NullTime
+		if err := rows.Scan(&c.ID, &c.ProductID, &price, &currency, &c.EffectiveFrom, &createdBy, &c.CreatedAt, &appliedAt); err != nil {
+			return nil, err
+		}
+		if c.Price, err = money.Parse(price, currency); err != nil {
+			return nil, fmt.Errorf("failed to parse price change: %w", err)
+		}
+		c.CreatedBy = createdBy.String
+		if appliedAt.Valid {
+			c.AppliedAt = &appliedAt.Time
+		}
+		history = append(history, c)
+	}
+	return history, rows.Err()
+}
+
+// ApplyDuePrices marks due changes applied and, in the same statement, sets
+// each affected product to its latest price in effect. That is not always
+// the change being applied: a direct edit made after it wins.
+func (r *Repository) ApplyDuePrices(ctx context.Context, now time.Time) ([]product.Product, error) {
+	const query = `
+		WITH due AS (
+			UPDATE product_prices SET applied_at = $1
+			WHERE applied_at IS NULL AND effective_from <= $1
+			RETURNING product_id
+		), latest AS (
+			SELECT DISTINCT ON (product_id) product_id, price AS new_price, currency AS new_currency
+			FROM product_prices
+			WHERE product_id IN (SELECT product_id FROM due) AND effective_from <= $1
+			ORDER BY product_id, effective_from DESC
+		)
+		UPDATE products
+		SET price = latest.new_price, currency = latest.new_currency, updated_at = $1
+		FROM latest
+		WHERE products.id = latest.product_id AND products.deleted_at IS NULL
+		RETURNING ` + productColumns
+	rows, err := txn.Executor(ctx, r.db).QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var products []product.Product
+	for rows.Next() {
+		p, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, *p)
+	}
+	return products, rows.Err()
+}
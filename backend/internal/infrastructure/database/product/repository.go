@@ -3,8 +3,16 @@ package product
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"foodie/backend/internal/domain/product"
+	"foodie/backend/internal/infrastructure/database/txn"
+	"foodie/backend/pkg/money"
+	timeutils "foodie/backend/pkg/utils/time"
 )
 
 // Repository implements product.Repository using SQL.
@@ -17,29 +25,207 @@ func NewRepository(db *sql.DB) *Repository {
 	return &Repository{db: db}
 }
 
+const productColumns = `id, restaurant_id, category_id, name, price, currency, modifier_groups,
+	stock_mode, stock_available, daily_quota, product_image, product_thumbnail, availability,
+	created_at, updated_at, deleted_at`
+
+// productSelect reads the columns of productColumns from productsFrom, with
+// the price currently in effect in place of the stored one. The stored price
+// lags behind a scheduled change until the scheduler applies it.
+const productSelect = `id, restaurant_id, category_id, name, ` + effectivePrice + `, ` + effectiveCurrency + `, modifier_groups,
+	stock_mode, stock_available, daily_quota, product_image, product_thumbnail, availability,
+	created_at, updated_at, deleted_at`
+
+// productsFrom joins every product to the latest price change that has taken effect.
+const productsFrom = `products LEFT JOIN LATERAL (
+		SELECT pp.price AS effective_price, pp.currency AS effective_currency
+		FROM product_prices pp
+		WHERE pp.product_id = products.id AND pp.effective_from <= NOW()
+		ORDER BY pp.effective_from DESC
+		LIMIT 1
+	) AS current_price ON TRUE`
+
+const (
+	effectivePrice    = `COALESCE(current_price.effective_price, products.price)`
+	effectiveCurrency = `COALESCE(current_price.effective_currency, products.currency)`
+)
+
 // Save inserts a new product row.
 func (r *Repository) Save(ctx context.Context, p *product.Product) error {
-	const query = `INSERT INTO products (id, restaurant_id, name, price, created_at) VALUES ($1, $2, $3, $4, $5)`
-	_, err := r.db.ExecContext(ctx, query, p.ID, p.RestaurantID, p.Name, p.Price, p.CreatedAt)
+	modifierGroups, err := marshalModifierGroups(p.ModifierGroups)
+	if err != nil {
+		return err
+	}
+	availability, slots, err := marshalAvailability(p.Availability)
+	if err != nil {
+		return err
+	}
+
+	const query = `INSERT INTO products (` + productColumns + `, availability_slots)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`
+	_, err = txn.Executor(ctx, r.db).ExecContext(ctx, query,
+		p.ID, p.RestaurantID, nullString(p.CategoryID), p.Name, p.Price.Decimal(), p.Price.Currency, modifierGroups,
+		stockMode(p.Stock), p.Stock.Available, p.Stock.DailyQuota,
+		nullString(p.ImageURL), nullString(p.ThumbnailURL), availability,
+		p.CreatedAt, p.UpdatedAt, p.DeletedAt, slots,
+	)
 	return err
 }
 
+// Update writes the editable fields of a product, including its soft-delete marker.
+// Returns product.ErrNotFound if the product does not exist or was already deleted.
+func (r *Repository) Update(ctx context.Context, p *product.Product) error {
+	modifierGroups, err := marshalModifierGroups(p.ModifierGroups)
+	if err != nil {
+		return err
+	}
+	availability, slots, err := marshalAvailability(p.Availability)
+	if err != nil {
+		return err
+	}
+
+	const query = `
+		UPDATE products
+		SET category_id = $2, name = $3, price = $4, currency = $5, modifier_groups = $6,
+			availability = $7, availability_slots = $8, updated_at = $9, deleted_at = $10
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	result, err := txn.Executor(ctx, r.db).ExecContext(ctx, query,
+		p.ID, nullString(p.CategoryID), p.Name, p.Price.Decimal(), p.Price.Currency, modifierGroups,
+		availability, slots, p.UpdatedAt, p.DeletedAt,
+	)
+	return requireRow(result, err)
+}
+
+// nullString stores an empty optional reference as NULL.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// marshalModifierGroups serializes modifier groups for the JSONB column.
+func marshalModifierGroups(groups []product.ModifierGroup) ([]byte, error) {
+	if groups == nil {
+		groups = []product.ModifierGroup{}
+	}
+	data, err := json.Marshal(groups)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal modifier groups: %w", err)
+	}
+	return data, nil
+}
+
+// marshalAvailability serializes availability windows for the availability
+// column, and their slots for the availability_slots column listings filter on.
+func marshalAvailability(windows []timeutils.Window) (availability, slots []byte, err error) {
+	if windows == nil {
+		windows = []timeutils.Window{}
+	}
+	if availability, err = json.Marshal(windows); err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal availability: %w", err)
+	}
+	if slots, err = json.Marshal(timeutils.SlotsOf(windows)); err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal availability slots: %w", err)
+	}
+	return availability, slots, nil
+}
+
 // FindByID loads a product by its ID.
 func (r *Repository) FindByID(ctx context.Context, id string) (*product.Product, error) {
-	const query = `SELECT id, restaurant_id, name, price, created_at FROM products WHERE id = $1`
-	row := r.db.QueryRowContext(ctx, query, id)
-
-	var p product.Product
-	if err := row.Scan(&p.ID, &p.RestaurantID, &p.Name, &p.Price, &p.CreatedAt); err != nil {
-		return nil, err
+	const query = `SELECT ` + productSelect + ` FROM ` + productsFrom + ` WHERE id = $1 AND deleted_at IS NULL`
+	p, err := scanProduct(txn.Executor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, product.ErrNotFound
 	}
-	return &p, nil
+	return p, err
 }
 
 // FindByRestaurant loads all products for a restaurant.
 func (r *Repository) FindByRestaurant(ctx context.Context, restaurantID string) ([]product.Product, error) {
-	const query = `SELECT id, restaurant_id, name, price, created_at FROM products WHERE restaurant_id = $1`
-	rows, err := r.db.QueryContext(ctx, query, restaurantID)
+	const query = `SELECT ` + productSelect + ` FROM ` + productsFrom + ` WHERE restaurant_id = $1 AND deleted_at IS NULL`
+	rows, err := txn.Executor(ctx, r.db).QueryContext(ctx, query, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var products []product.Product
+	for rows.Next() {
+		p, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, *p)
+	}
+	return products, rows.Err()
+}
+
+// SetStock replaces a product's stock mode and levels.
+func (r *Repository) SetStock(ctx context.Context, productID string, stock product.Stock) error {
+	const query = `
+		UPDATE products
+		SET stock_mode = $2, stock_available = $3, daily_quota = $4, updated_at = $5
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	result, err := txn.Executor(ctx, r.db).ExecContext(ctx, query,
+		productID, stockMode(stock), stock.Available, stock.DailyQuota, time.Now(),
+	)
+	return requireRow(result, err)
+}
+
+// SetImage replaces a product's image URLs.
+func (r *Repository) SetImage(ctx context.Context, productID, imageURL, thumbnailURL string) error {
+	const query = `
+		UPDATE products
+		SET product_image = $2, product_thumbnail = $3, updated_at = $4
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	result, err := txn.Executor(ctx, r.db).ExecContext(ctx, query,
+		productID, nullString(imageURL), nullString(thumbnailURL), time.Now(),
+	)
+	return requireRow(result, err)
+}
+
+// ReserveStock decrements a tracked product's stock in a single conditional
+// UPDATE, so concurrent orders can never oversell it.
+func (r *Repository) ReserveStock(ctx context.Context, productID string, quantity int) error {
+	const query = `
+		UPDATE products
+		SET stock_available = CASE
+			WHEN stock_mode = 'unlimited' THEN stock_available
+			ELSE stock_available - $2
+		END
+		WHERE id = $1 AND deleted_at IS NULL
+			AND (stock_mode = 'unlimited' OR stock_available >= $2)
+	`
+	err := requireRow(txn.Executor(ctx, r.db).ExecContext(ctx, query, productID, quantity))
+	if errors.Is(err, product.ErrNotFound) {
+		return &product.OutOfStockError{ProductID: productID, Requested: quantity}
+	}
+	return err
+}
+
+// ReleaseStock gives reserved units back to a tracked product.
+func (r *Repository) ReleaseStock(ctx context.Context, productID string, quantity int) error {
+	const query = `
+		UPDATE products
+		SET stock_available = CASE
+			WHEN stock_mode = 'daily' THEN LEAST(stock_available + $2, daily_quota)
+			ELSE stock_available + $2
+		END
+		WHERE id = $1 AND stock_mode <> 'unlimited'
+	`
+	_, err := txn.Executor(ctx, r.db).ExecContext(ctx, query, productID, quantity)
+	return err
+}
+
+// ResetDailyStock refills every daily product to its quota.
+func (r *Repository) ResetDailyStock(ctx context.Context) ([]product.Product, error) {
+	const query = `
+		UPDATE products
+		SET stock_available = daily_quota
+		WHERE stock_mode = 'daily' AND deleted_at IS NULL AND stock_available <> daily_quota
+		RETURNING ` + productColumns
+	rows, err := txn.Executor(ctx, r.db).QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -47,11 +233,172 @@ func (r *Repository) FindByRestaurant(ctx context.Context, restaurantID string)
 
 	var products []product.Product
 	for rows.Next() {
-		var p product.Product
-		if err := rows.Scan(&p.ID, &p.RestaurantID, &p.Name, &p.Price, &p.CreatedAt); err != nil {
+		p, err := scanProduct(rows)
+		if err != nil {
 			return nil, err
 		}
-		products = append(products, p)
+		products = append(products, *p)
 	}
 	return products, rows.Err()
 }
+
+// requireRow maps an UPDATE that matched no live product to product.ErrNotFound.
+func requireRow(result sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return product.ErrNotFound
+	}
+	return nil
+}
+
+// stockMode defaults products built without a stock to unlimited.
+func stockMode(stock product.Stock) string {
+	if stock.Mode == "" {
+		return string(product.StockUnlimited)
+	}
+	return string(stock.Mode)
+}
+
+// sortColumns maps each sort field to the column it orders by.
+var sortColumns = map[product.SortField]string{
+	product.SortByName:   "name",
+	product.SortByPrice:  effectivePrice,
+	product.SortByNewest: "created_at",
+}
+
+// FindByFilter returns one page of products matching filter and the total number of matches.
+func (r *Repository) FindByFilter(ctx context.Context, filter product.Filter) ([]product.Product, int, error) {
+	where, args := filterConditions(filter)
+	exec := txn.Executor(ctx, r.db)
+
+	var total int
+	if err := exec.QueryRowContext(ctx, `SELECT COUNT(*) FROM `+productsFrom+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	column, ok := sortColumns[filter.SortBy]
+	if !ok {
+		column = sortColumns[product.SortByName]
+	}
+	direction := "ASC"
+	if filter.SortDesc {
+		direction = "DESC"
+	}
+
+	// column comes from sortColumns, so it is safe to interpolate
+	query := `SELECT ` + productSelect + ` FROM ` + productsFrom + where +
+		fmt.Sprintf(` ORDER BY %s %s, id %s LIMIT $%d OFFSET $%d`, column, direction, direction, len(args)+1, len(args)+2)
+	rows, err := exec.QueryContext(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var products []product.Product
+	for rows.Next() {
+		p, err := scanProduct(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		products = append(products, *p)
+	}
+
+	return products, total, rows.Err()
+}
+
+// filterConditions builds the WHERE clause and its arguments for filter,
+// to be used with productsFrom.
+func filterConditions(filter product.Filter) (string, []any) {
+	conditions := []string{"deleted_at IS NULL"}
+	var args []any
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.RestaurantID != "" {
+		add("restaurant_id = $%d", filter.RestaurantID)
+	}
+	if filter.CategoryID != "" {
+		add("category_id = $%d", filter.CategoryID)
+	}
+	if filter.Name != "" {
+		add(`name ILIKE '%%' || $%d || '%%' ESCAPE '\'`, likeEscaper.Replace(filter.Name))
+	}
+	if filter.MinPrice != nil {
+		add(effectiveCurrency+" = $%d", filter.MinPrice.Currency)
+		add(effectivePrice+" >= $%d", filter.MinPrice.Decimal())
+	}
+	if filter.MaxPrice != nil {
+		add(effectiveCurrency+" = $%d", filter.MaxPrice.Currency)
+		add(effectivePrice+" <= $%d", filter.MaxPrice.Decimal())
+	}
+	if filter.AvailableAt != nil {
+		args = append(args, int(filter.AvailableAt.Day), int(filter.AvailableAt.Clock))
+		day, minute := len(args)-1, len(args)
+		conditions = append(conditions,
+			availableCondition("availability_slots", day, minute),
+			`(category_id IS NULL OR category_id IN (SELECT id FROM categories WHERE `+
+				availableCondition("categories.availability_slots", day, minute)+`))`,
+		)
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// availableCondition matches rows whose slots column, an availability_slots
+// array, is empty or has a slot containing the weekday and minute parameters.
+func availableCondition(column string, day, minute int) string {
+	return fmt.Sprintf(`(%[1]s = '[]'::jsonb OR EXISTS (
+		SELECT 1 FROM jsonb_to_recordset(%[1]s) AS slot(day INT, start_minute INT, end_minute INT)
+		WHERE slot.day = $%[2]d AND slot.start_minute <= $%[3]d AND $%[3]d < slot.end_minute))`, column, day, minute)
+}
+
+// likeEscaper escapes LIKE wildcards so user input matches literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// scanner is satisfied by both *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanProduct reads one product row; the price is parsed exactly from its decimal text.
+func scanProduct(s scanner) (*product.Product, error) {
+	var p product.Product
+	var price, currency, stockMode string
+	var modifierGroups, availability []byte
+	var categoryID, imageURL, thumbnailURL sql.NullString
+	var deletedAt sql.NullTime
+	err := s.Scan(
+		&p.ID, &p.RestaurantID, &categoryID, &p.Name, &price, &currency, &modifierGroups,
+		&stockMode, &p.Stock.Available, &p.Stock.DailyQuota, &imageURL, &thumbnailURL, &availability,
+		&p.CreatedAt, &p.UpdatedAt, &deletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	p.CategoryID = categoryID.String
+	p.ImageURL = imageURL.String
+	p.ThumbnailURL = thumbnailURL.String
+	p.Stock.Mode = product.StockMode(stockMode)
+
+	if p.Price, err = money.Parse(price, currency); err != nil {
+		return nil, fmt.Errorf("failed to parse product price: %w", err)
+	}
+	if err := json.Unmarshal(modifierGroups, &p.ModifierGroups); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal modifier groups: %w", err)
+	}
+	if err := json.Unmarshal(availability, &p.Availability); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal availability: %w", err)
+	}
+	if deletedAt.Valid {
+		p.DeletedAt = &deletedAt.Time
+	}
+	return &p, nil
+}
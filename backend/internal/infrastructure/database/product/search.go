@@ -0,0 +1,66 @@
+package product
+
+import (
+	"context"
+	"fmt"
+
+	"foodie/backend/internal/domain/product"
+	"foodie/backend/internal/infrastructure/database/fulltext"
+	"foodie/backend/internal/infrastructure/database/txn"
+)
+
+// Search runs a full-text query against products.search_vector, ranked by ts_rank.
+func (r *Repository) Search(ctx context.Context, query product.SearchQuery) ([]product.SearchResult, int, error) {
+	tsQuery := fulltext.PrefixQuery(query.Text)
+	if tsQuery == "" {
+		return nil, 0, nil
+	}
+
+	where, args := filterConditions(product.Filter{
+		RestaurantID: query.RestaurantID,
+		MinPrice:     query.MinPrice,
+		MaxPrice:     query.MaxPrice,
+		AvailableAt:  query.AvailableAt,
+	})
+	args = append(args, tsQuery)
+	tsQueryArg := len(args)
+	where += fmt.Sprintf(` AND search_vector @@ to_tsquery('simple', $%d)`, tsQueryArg) +
+		` AND (category_id IS NULL OR category_id IN (SELECT id FROM categories WHERE visible))`
+	exec := txn.Executor(ctx, r.db)
+
+	var total int
+	if err := exec.QueryRowContext(ctx, `SELECT COUNT(*) FROM `+productsFrom+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rank := fmt.Sprintf(`ts_rank(search_vector, to_tsquery('simple', $%d))`, tsQueryArg)
+	sqlQuery := `SELECT ` + productSelect + `, ` + rank + ` AS rank FROM ` + productsFrom + where +
+		fmt.Sprintf(` ORDER BY rank DESC, name, id LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+	rows, err := exec.QueryContext(ctx, sqlQuery, append(args, query.Limit, query.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var results []product.SearchResult
+	for rows.Next() {
+		var result product.SearchResult
+		p, err := scanProduct(rankedRow{scanner: rows, rank: &result.Rank})
+		if err != nil {
+			return nil, 0, err
+		}
+		result.Product = *p
+		results = append(results, result)
+	}
+	return results, total, rows.Err()
+}
+
+// rankedRow scans a product row followed by its rank column.
+type rankedRow struct {
+	scanner
+	rank *float64
+}
+
+func (r rankedRow) Scan(dest ...any) error {
+	return r.scanner.Scan(append(dest, r.rank)...)
+}
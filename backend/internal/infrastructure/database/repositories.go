@@ -3,26 +3,40 @@ package database
 import (
 	"database/sql"
 
+	"foodie/backend/internal/domain/category"
 	"foodie/backend/internal/domain/order"
+	"foodie/backend/internal/domain/payment"
 	"foodie/backend/internal/domain/product"
+	"foodie/backend/internal/domain/restaurant"
+	"foodie/backend/internal/domain/user"
+	categoryrepo "foodie/backend/internal/infrastructure/database/category"
 	orderrepo "foodie/backend/internal/infrastructure/database/order"
+	paymentrepo "foodie/backend/internal/infrastructure/database/payment"
 	productrepo "foodie/backend/internal/infrastructure/database/product"
+	restaurantrepo "foodie/backend/internal/infrastructure/database/restaurant"
+	userrepo "foodie/backend/internal/infrastructure/database/user"
 )
 
 // Repositories bundles every repository implementation the application needs.
-// As new modules (user, restaurant, payment, etc.) are implemented,
+// As new modules are implemented,
 // add them here and initialize them in NewRepositories.
 type Repositories struct {
 	Order   order.Repository
 	Product product.Repository
-	// User       user.Repository
-	// Restaurant restaurant.Repository
-	// Payment    payment.Repository
+	Payment payment.Repository
+	// Category holds the sections of each restaurant's menu.
+	Category category.Repository
+	// Restaurant holds each restaurant's address, status and opening hours.
+	Restaurant restaurant.Repository
+	// RestaurantOwners resolves which users may manage a restaurant.
+	RestaurantOwners restaurant.OwnershipRepository
+	// User holds the accounts that sign in to the API.
+	User user.Repository
 }
 
 // NewRepositories creates and initializes all repositories for the application.
 //
-// When adding a new module (e.g., user, restaurant), follow these steps:
+// When adding a new module, follow these steps:
 // 1. Create domain/<module> with Repository interface
 // 2. Create infrastructure/database/<module>/repository.go with implementation
 // 3. Add field to Repositories struct above
@@ -35,8 +49,13 @@ func NewRepositories(sqlDB *sql.DB) (*Repositories, error) {
 	}
 
 	return &Repositories{
-		Order:   orderrepo.NewRepository(sqlDB),
-		Product: productrepo.NewRepository(sqlDB),
+		Order:            orderrepo.NewRepository(sqlDB),
+		Product:          productrepo.NewRepository(sqlDB),
+		Payment:          paymentrepo.NewRepository(sqlDB),
+		Category:         categoryrepo.NewRepository(sqlDB),
+		Restaurant:       restaurantrepo.NewRepository(sqlDB),
+		RestaurantOwners: restaurantrepo.NewOwnershipRepository(sqlDB),
+		User:             userrepo.NewRepository(sqlDB),
 	}, nil
 }
 
@@ -0,0 +1,29 @@
+package restaurant
+
+import (
+	"context"
+	"database/sql"
+
+	"foodie/backend/internal/infrastructure/database/txn"
+)
+
+// OwnershipRepository is a Postgres implementation of restaurant.OwnershipRepository
+// backed by the restaurant_owners table.
+type OwnershipRepository struct {
+	db *sql.DB
+}
+
+// NewOwnershipRepository creates a new SQL-based restaurant ownership repository.
+func NewOwnershipRepository(db *sql.DB) *OwnershipRepository {
+	return &OwnershipRepository{db: db}
+}
+
+// IsOwner reports whether userID is listed as an owner of restaurantID.
+func (r *OwnershipRepository) IsOwner(ctx context.Context, restaurantID, userID string) (bool, error) {
+	const query = `SELECT EXISTS (
+		SELECT 1 FROM restaurant_owners WHERE restaurant_id = $1 AND user_id = $2
+	)`
+	var owns bool
+	err := txn.Executor(ctx, r.db).QueryRowContext(ctx, query, restaurantID, userID).Scan(&owns)
+	return owns, err
+}
@@ -0,0 +1,197 @@
+package restaurant
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"foodie/backend/internal/domain/restaurant"
+	"foodie/backend/internal/infrastructure/database/txn"
+	timeutils "foodie/backend/pkg/utils/time"
+)
+
+// Repository implements restaurant.Repository using SQL.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository creates a new SQL-based restaurant repository.
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+const restaurantColumns = `id, name, address, latitude, longitude, time_zone, status, opening_hours,
+	created_at, updated_at, deleted_at`
+
+// Save inserts a restaurant row and lists ownerID in restaurant_owners, in
+// a single statement so a restaurant never exists without its owner.
+func (r *Repository) Save(ctx context.Context, rest *restaurant.Restaurant, ownerID string) error {
+	openingHours, err := marshalOpeningHours(rest.OpeningHours)
+	if err != nil {
+		return err
+	}
+
+	const query = `
+		WITH inserted AS (
+			INSERT INTO restaurants (` + restaurantColumns + `)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			RETURNING id, created_at
+		)
+		INSERT INTO restaurant_owners (restaurant_id, user_id, created_at)
+		SELECT id, $12, created_at FROM inserted
+		ON CONFLICT DO NOTHING
+	`
+	_, err = txn.Executor(ctx, r.db).ExecContext(ctx, query,
+		rest.ID, rest.Name, rest.Address, rest.Location.Latitude, rest.Location.Longitude,
+		rest.TimeZone, string(rest.Status), openingHours, rest.CreatedAt, rest.UpdatedAt, rest.DeletedAt,
+		ownerID,
+	)
+	return err
+}
+
+// Update writes the editable fields of a restaurant, including its soft-delete marker.
+// Returns restaurant.ErrNotFound if the restaurant does not exist or was already deleted.
+func (r *Repository) Update(ctx context.Context, rest *restaurant.Restaurant) error {
+	openingHours, err := marshalOpeningHours(rest.OpeningHours)
+	if err != nil {
+		return err
+	}
+
+	const query = `
+		UPDATE restaurants
+		SET name = $2, address = $3, latitude = $4, longitude = $5, time_zone = $6, status = $7,
+			opening_hours = $8, updated_at = $9, deleted_at = $10
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	result, err := txn.Executor(ctx, r.db).ExecContext(ctx, query,
+		rest.ID, rest.Name, rest.Address, rest.Location.Latitude, rest.Location.Longitude,
+		rest.TimeZone, string(rest.Status), openingHours, rest.UpdatedAt, rest.DeletedAt,
+	)
+	return requireRow(result, err)
+}
+
+// marshalOpeningHours serializes opening hours for the JSONB column.
+func marshalOpeningHours(windows []timeutils.Window) ([]byte, error) {
+	if windows == nil {
+		windows = []timeutils.Window{}
+	}
+	data, err := json.Marshal(windows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal opening hours: %w", err)
+	}
+	return data, nil
+}
+
+// FindByID loads a restaurant that has not been deleted.
+func (r *Repository) FindByID(ctx context.Context, id string) (*restaurant.Restaurant, error) {
+	const query = `SELECT ` + restaurantColumns + ` FROM restaurants WHERE id = $1 AND deleted_at IS NULL`
+	rest, err := scanRestaurant(txn.Executor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, restaurant.ErrNotFound
+	}
+	return rest, err
+}
+
+// FindByFilter lists one page of restaurants ordered by name, with the total number of matches.
+func (r *Repository) FindByFilter(ctx context.Context, filter restaurant.Filter) ([]restaurant.Restaurant, int, error) {
+	where, args := filterConditions(filter)
+	exec := txn.Executor(ctx, r.db)
+
+	var total int
+	if err := exec.QueryRowContext(ctx, `SELECT COUNT(*) FROM restaurants`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + restaurantColumns + ` FROM restaurants` + where +
+		fmt.Sprintf(` ORDER BY name, id LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+	rows, err := exec.QueryContext(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var restaurants []restaurant.Restaurant
+	for rows.Next() {
+		rest, err := scanRestaurant(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		restaurants = append(restaurants, *rest)
+	}
+	return restaurants, total, rows.Err()
+}
+
+// filterConditions builds the WHERE clause and its arguments for filter.
+func filterConditions(filter restaurant.Filter) (string, []any) {
+	conditions := []string{"deleted_at IS NULL"}
+	var args []any
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Name != "" {
+		add(`name ILIKE '%%' || $%d || '%%' ESCAPE '\'`, likeEscaper.Replace(filter.Name))
+	}
+	if filter.OwnerID != "" {
+		add("id IN (SELECT restaurant_id FROM restaurant_owners WHERE user_id = $%d)", filter.OwnerID)
+	}
+	if len(filter.Statuses) > 0 {
+		placeholders := make([]string, 0, len(filter.Statuses))
+		for _, status := range filter.Statuses {
+			args = append(args, string(status))
+			placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+		}
+		conditions = append(conditions, "status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// likeEscaper escapes LIKE wildcards so user input matches literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// requireRow maps a statement that matched no restaurant to restaurant.ErrNotFound.
+func requireRow(result sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return restaurant.ErrNotFound
+	}
+	return nil
+}
+
+// scanner is satisfied by both *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanRestaurant(s scanner) (*restaurant.Restaurant, error) {
+	var rest restaurant.Restaurant
+	var status string
+	var openingHours []byte
+	var deletedAt sql.NullTime
+	err := s.Scan(
+		&rest.ID, &rest.Name, &rest.Address, &rest.Location.Latitude, &rest.Location.Longitude,
+		&rest.TimeZone, &status, &openingHours, &rest.CreatedAt, &rest.UpdatedAt, &deletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	rest.Status = restaurant.Status(status)
+	if deletedAt.Valid {
+		rest.DeletedAt = &deletedAt.Time
+	}
+	if err := json.Unmarshal(openingHours, &rest.OpeningHours); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal opening hours: %w", err)
+	}
+	return &rest, nil
+}
@@ -0,0 +1,60 @@
+package restaurant
+
+import (
+	"context"
+	"fmt"
+
+	"foodie/backend/internal/domain/restaurant"
+	"foodie/backend/internal/infrastructure/database/fulltext"
+	"foodie/backend/internal/infrastructure/database/txn"
+)
+
+// Search runs a full-text query against restaurants.search_vector, ranked by ts_rank.
+func (r *Repository) Search(ctx context.Context, query restaurant.SearchQuery) ([]restaurant.SearchResult, int, error) {
+	tsQuery := fulltext.PrefixQuery(query.Text)
+	if tsQuery == "" {
+		return nil, 0, nil
+	}
+
+	where, args := filterConditions(restaurant.Filter{Statuses: query.Statuses})
+	args = append(args, tsQuery)
+	tsQueryArg := len(args)
+	where += fmt.Sprintf(` AND search_vector @@ to_tsquery('simple', $%d)`, tsQueryArg)
+	exec := txn.Executor(ctx, r.db)
+
+	var total int
+	if err := exec.QueryRowContext(ctx, `SELECT COUNT(*) FROM restaurants`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rank := fmt.Sprintf(`ts_rank(search_vector, to_tsquery('simple', $%d))`, tsQueryArg)
+	sqlQuery := `SELECT ` + restaurantColumns + `, ` + rank + ` AS rank FROM restaurants` + where +
+		fmt.Sprintf(` ORDER BY rank DESC, name, id LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+	rows, err := exec.QueryContext(ctx, sqlQuery, append(args, query.Limit, query.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var results []restaurant.SearchResult
+	for rows.Next() {
+		var result restaurant.SearchResult
+		rest, err := scanRestaurant(rankedRow{scanner: rows, rank: &result.Rank})
+		if err != nil {
+			return nil, 0, err
+		}
+		result.Restaurant = *rest
+		results = append(results, result)
+	}
+	return results, total, rows.Err()
+}
+
+// rankedRow scans a restaurant row followed by its rank column.
+type rankedRow struct {
+	scanner
+	rank *float64
+}
+
+func (r rankedRow) Scan(dest ...any) error {
+	return r.scanner.Scan(append(dest, r.rank)...)
+}
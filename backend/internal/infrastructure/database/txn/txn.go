@@ -0,0 +1,75 @@
+package txn
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// DBTX is the subset of *sql.DB and *sql.Tx used by repositories.
+// Repositories obtain one via Executor so that the same code runs inside
+// or outside a transaction.
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Manager runs a function inside a database transaction.
+type Manager interface {
+	// WithinTransaction calls fn with a context carrying an open transaction.
+	// The transaction is committed if fn returns nil and rolled back otherwise.
+	// Nested calls join the outer transaction.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txKey struct{}
+
+// SQLManager implements Manager on top of *sql.DB.
+type SQLManager struct {
+	db *sql.DB
+}
+
+// NewManager creates a new SQL transaction manager.
+func NewManager(db *sql.DB) *SQLManager {
+	return &SQLManager{db: db}
+}
+
+// WithinTransaction runs fn inside a transaction bound to the returned context.
+func (m *SQLManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// Executor returns the transaction bound to ctx, or db if there is none.
+func Executor(ctx context.Context, db *sql.DB) DBTX {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
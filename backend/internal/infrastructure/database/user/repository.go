@@ -0,0 +1,71 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"foodie/backend/internal/domain/user"
+	"foodie/backend/internal/infrastructure/database/txn"
+)
+
+// Repository implements user.Repository using SQL.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository creates a new SQL-based user repository.
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+const userColumns = `id, email, name, role, password_hash, created_at, updated_at`
+
+// Save inserts a user row. A taken email is detected by the unique
+// constraint, so concurrent registrations cannot both succeed.
+func (r *Repository) Save(ctx context.Context, u *user.User) error {
+	const query = `INSERT INTO users (` + userColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (email) DO NOTHING`
+	result, err := txn.Executor(ctx, r.db).ExecContext(ctx, query,
+		u.ID, u.Email, u.Name, string(u.Role), u.PasswordHash, u.CreatedAt, u.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return user.ErrEmailTaken
+	}
+	return nil
+}
+
+// FindByID loads a user by ID.
+func (r *Repository) FindByID(ctx context.Context, id string) (*user.User, error) {
+	const query = `SELECT ` + userColumns + ` FROM users WHERE id = $1`
+	return r.findOne(ctx, query, id)
+}
+
+// FindByEmail loads a user by normalized email.
+func (r *Repository) FindByEmail(ctx context.Context, email string) (*user.User, error) {
+	const query = `SELECT ` + userColumns + ` FROM users WHERE email = $1`
+	return r.findOne(ctx, query, email)
+}
+
+func (r *Repository) findOne(ctx context.Context, query string, arg any) (*user.User, error) {
+	var u user.User
+	var role string
+	err := txn.Executor(ctx, r.db).QueryRowContext(ctx, query, arg).Scan(
+		&u.ID, &u.Email, &u.Name, &role, &u.PasswordHash, &u.CreatedAt, &u.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, user.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	u.Role = user.Role(role)
+	return &u, nil
+}
@@ -0,0 +1,50 @@
+package external
+
+import (
+	"fmt"
+
+	"foodie/backend/pkg/config"
+)
+
+// NewPaymentGateway creates a payment gateway based on configuration.
+// It reads from environment variables:
+//   - PAYMENT_GATEWAY: "mock" or "stripe" (default: "mock")
+//   - MOCK_PAYMENT_SUCCEED: whether the mock gateway approves payments (default: true)
+//   - STRIPE_API_KEY: Stripe secret key (required if PAYMENT_GATEWAY=stripe)
+func NewPaymentGateway() (PaymentGateway, error) {
+	gatewayType := config.Get("PAYMENT_GATEWAY", "mock")
+
+	switch gatewayType {
+	case "mock":
+		return NewMockPaymentGateway(config.GetBool("MOCK_PAYMENT_SUCCEED", true)), nil
+	case "stripe":
+		apiKey := config.Get("STRIPE_API_KEY", "")
+		if apiKey == "" {
+			return nil, fmt.Errorf("STRIPE_API_KEY is required for stripe payment gateway")
+		}
+		return NewStripeGateway(apiKey), nil
+	default:
+		return nil, fmt.Errorf("unsupported payment gateway: %s", gatewayType)
+	}
+}
+
+// NewMapsService creates a maps service based on configuration.
+// It reads from environment variables:
+//   - MAPS_PROVIDER: "mock" or "google" (default: "mock")
+//   - GOOGLE_MAPS_API_KEY: Google Maps API key (required if MAPS_PROVIDER=google)
+func NewMapsService() (MapsService, error) {
+	provider := config.Get("MAPS_PROVIDER", "mock")
+
+	switch provider {
+	case "mock":
+		return NewMockMapsService(), nil
+	case "google":
+		apiKey := config.Get("GOOGLE_MAPS_API_KEY", "")
+		if apiKey == "" {
+			return nil, fmt.Errorf("GOOGLE_MAPS_API_KEY is required for google maps provider")
+		}
+		return NewGoogleMapsService(apiKey), nil
+	default:
+		return nil, fmt.Errorf("unsupported maps provider: %s", provider)
+	}
+}
@@ -3,22 +3,27 @@ package external
 import (
 	"context"
 	"fmt"
+
+	"foodie/backend/pkg/money"
 )
 
 // PaymentGateway defines the interface for payment processing.
+// Every call carries an idempotency key that implementations must pass on to
+// the provider, so a retried call with the same key has no further effect.
 type PaymentGateway interface {
 	AuthorizePayment(ctx context.Context, req PaymentRequest) (*PaymentResponse, error)
-	CapturePayment(ctx context.Context, paymentID string, amount float64) error
-	RefundPayment(ctx context.Context, paymentID string, amount float64) error
+	CapturePayment(ctx context.Context, paymentID string, amount money.Money, idempotencyKey string) error
+	RefundPayment(ctx context.Context, paymentID string, amount money.Money, idempotencyKey string) error
 }
 
 // PaymentRequest represents a payment authorization request.
 type PaymentRequest struct {
 	OrderID       string
-	Amount        float64
-	Currency      string
+	Amount        money.Money
 	PaymentMethod string
 	CardToken     string // For card payments
+	// IdempotencyKey identifies the authorization across retries.
+	IdempotencyKey string
 }
 
 // PaymentResponse represents the response from payment gateway.
@@ -52,7 +57,7 @@ func (g *MockPaymentGateway) AuthorizePayment(ctx context.Context, req PaymentRe
 }
 
 // CapturePayment simulates payment capture.
-func (g *MockPaymentGateway) CapturePayment(ctx context.Context, paymentID string, amount float64) error {
+func (g *MockPaymentGateway) CapturePayment(ctx context.Context, paymentID string, amount money.Money, idempotencyKey string) error {
 	if !g.shouldSucceed {
 		return fmt.Errorf("payment capture failed")
 	}
@@ -60,7 +65,7 @@ func (g *MockPaymentGateway) CapturePayment(ctx context.Context, paymentID strin
 }
 
 // RefundPayment simulates payment refund.
-func (g *MockPaymentGateway) RefundPayment(ctx context.Context, paymentID string, amount float64) error {
+func (g *MockPaymentGateway) RefundPayment(ctx context.Context, paymentID string, amount money.Money, idempotencyKey string) error {
 	if !g.shouldSucceed {
 		return fmt.Errorf("payment refund failed")
 	}
@@ -85,13 +90,13 @@ func (g *StripeGateway) AuthorizePayment(ctx context.Context, req PaymentRequest
 }
 
 // CapturePayment captures payment via Stripe API.
-func (g *StripeGateway) CapturePayment(ctx context.Context, paymentID string, amount float64) error {
+func (g *StripeGateway) CapturePayment(ctx context.Context, paymentID string, amount money.Money, idempotencyKey string) error {
 	// TODO: Implement Stripe API call
 	return fmt.Errorf("not implemented")
 }
 
 // RefundPayment refunds payment via Stripe API.
-func (g *StripeGateway) RefundPayment(ctx context.Context, paymentID string, amount float64) error {
+func (g *StripeGateway) RefundPayment(ctx context.Context, paymentID string, amount money.Money, idempotencyKey string) error {
 	// TODO: Implement Stripe API call
 	return fmt.Errorf("not implemented")
 }
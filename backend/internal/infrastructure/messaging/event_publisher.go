@@ -2,7 +2,13 @@ package messaging
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"fmt"
+	"log"
+	"time"
+
+	"foodie/backend/internal/infrastructure/database/txn"
 )
 
 // Event represents a domain event to be published.
@@ -50,24 +56,148 @@ func (p *InMemoryPublisher) Clear() {
 // OutboxPublisher implements outbox pattern for reliable event publishing.
 // Events are stored in database first, then published asynchronously.
 type OutboxPublisher struct {
-	db interface{} // TODO: Add database connection
+	db        *sql.DB
+	relay     Publisher // Broker that ProcessOutbox forwards to; may be nil for write-only use
+	batchSize int
 }
 
 // NewOutboxPublisher creates a new outbox-based event publisher.
-func NewOutboxPublisher(db interface{}) *OutboxPublisher {
-	return &OutboxPublisher{db: db}
+// relay is the broker publisher (usually *RabbitMQPublisher) used by ProcessOutbox;
+// it can be nil when the publisher is only used to record events.
+func NewOutboxPublisher(db *sql.DB, relay Publisher) *OutboxPublisher {
+	return &OutboxPublisher{
+		db:        db,
+		relay:     relay,
+		batchSize: 100,
+	}
 }
 
 // Publish stores event in outbox table within the same transaction.
+// Call it inside txn.Manager.WithinTransaction so the event is only
+// recorded if the surrounding business write commits.
 func (p *OutboxPublisher) Publish(ctx context.Context, event Event) error {
-	// TODO: Insert into outbox table
-	// This should be called within a database transaction
-	return fmt.Errorf("not implemented")
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+
+	occurredAt := time.Now()
+	if event.Timestamp > 0 {
+		occurredAt = time.Unix(event.Timestamp, 0)
+	}
+
+	const query = `INSERT INTO outbox (event_type, aggregate_id, payload, occurred_at)
+		VALUES ($1, $2, $3, $4)`
+
+	_, err = txn.Executor(ctx, p.db).ExecContext(ctx, query, event.Type, event.AggregateID, payload, occurredAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert outbox event: %w", err)
+	}
+	return nil
 }
 
 // ProcessOutbox processes events from outbox table and publishes them.
-// This should run as a background worker.
+// It forwards one batch of pending rows, in insertion order, to the relay
+// publisher and marks each as published. Rows are locked with SKIP LOCKED so
+// several relays can run concurrently. Processing stops at the first broker
+// failure to preserve ordering; the row is retried on the next call.
 func (p *OutboxPublisher) ProcessOutbox(ctx context.Context) error {
-	// TODO: Select events from outbox, publish, mark as processed
-	return fmt.Errorf("not implemented")
+	_, err := p.processBatch(ctx)
+	return err
+}
+
+// Run polls the outbox every interval and relays pending events until ctx is cancelled.
+// A full batch is followed immediately by another so that backlogs drain quickly.
+// This should run as a background worker.
+func (p *OutboxPublisher) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := p.processBatch(ctx)
+		if err != nil {
+			log.Printf("Outbox relay error: %v", err)
+		}
+		if err == nil && n == p.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// processBatch relays up to batchSize events and returns how many were published.
+func (p *OutboxPublisher) processBatch(ctx context.Context) (int, error) {
+	if p.relay == nil {
+		return 0, fmt.Errorf("outbox relay publisher is not configured")
+	}
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	const selectQuery = `SELECT id, event_type, aggregate_id, payload, occurred_at
+		FROM outbox
+		WHERE published_at IS NULL
+		ORDER BY id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED`
+
+	rows, err := tx.QueryContext(ctx, selectQuery, p.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to select outbox events: %w", err)
+	}
+
+	type outboxRow struct {
+		id    int64
+		event Event
+	}
+	var pending []outboxRow
+	for rows.Next() {
+		var row outboxRow
+		var payload []byte
+		var occurredAt time.Time
+		if err := rows.Scan(&row.id, &row.event.Type, &row.event.AggregateID, &payload, &occurredAt); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan outbox event: %w", err)
+		}
+		row.event.Payload = json.RawMessage(payload)
+		row.event.Timestamp = occurredAt.Unix()
+		pending = append(pending, row)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read outbox events: %w", err)
+	}
+
+	published := 0
+	for _, row := range pending {
+		if err := p.relay.Publish(ctx, row.event); err != nil {
+			const failQuery = `UPDATE outbox SET attempts = attempts + 1, last_error = $2 WHERE id = $1`
+			if _, updateErr := tx.ExecContext(ctx, failQuery, row.id, err.Error()); updateErr != nil {
+				return published, fmt.Errorf("failed to record outbox failure: %w", updateErr)
+			}
+			if commitErr := tx.Commit(); commitErr != nil {
+				return published, fmt.Errorf("failed to commit outbox batch: %w", commitErr)
+			}
+			return published, fmt.Errorf("failed to relay event %d (%s): %w", row.id, row.event.Type, err)
+		}
+
+		const sentQuery = `UPDATE outbox SET published_at = NOW(), attempts = attempts + 1, last_error = NULL WHERE id = $1`
+		if _, err := tx.ExecContext(ctx, sentQuery, row.id); err != nil {
+			return published, fmt.Errorf("failed to mark outbox event as published: %w", err)
+		}
+		published++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit outbox batch: %w", err)
+	}
+	return published, nil
 }
@@ -0,0 +1,37 @@
+package tasks
+
+import (
+	"context"
+	"log"
+)
+
+// HealthCheckTask performs periodic health checks.
+type HealthCheckTask struct {
+	logger *log.Logger
+}
+
+// NewHealthCheckTask creates a new health check task.
+func NewHealthCheckTask(logger *log.Logger) *HealthCheckTask {
+	return &HealthCheckTask{
+		logger: logger,
+	}
+}
+
+// Name returns the task name.
+func (t *HealthCheckTask) Name() string {
+	return "health_check"
+}
+
+// Run executes the health check task.
+func (t *HealthCheckTask) Run(ctx context.Context) error {
+	t.logger.Printf("Performing health check...")
+
+	// TODO: Implement health checks
+	// - Check database connectivity
+	// - Check cache connectivity
+	// - Check external services (payment gateway, maps, etc.)
+	// - Send metrics to monitoring system
+
+	t.logger.Printf("Health check completed")
+	return nil
+}
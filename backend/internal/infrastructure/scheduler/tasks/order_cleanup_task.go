@@ -0,0 +1,77 @@
+package tasks
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"foodie/backend/internal/domain/order"
+)
+
+// OrderCleanupTask cleans up old orders (e.g., cancelled orders older than 30 days).
+type OrderCleanupTask struct {
+	orderRepo order.Repository
+	logger    *log.Logger
+	olderThan time.Duration
+}
+
+// NewOrderCleanupTask creates a new order cleanup task.
+func NewOrderCleanupTask(orderRepo order.Repository, logger *log.Logger) *OrderCleanupTask {
+	return &OrderCleanupTask{
+		orderRepo: orderRepo,
+		logger:    logger,
+		olderThan: 30 * 24 * time.Hour, // 30 days
+	}
+}
+
+// Name returns the task name.
+func (t *OrderCleanupTask) Name() string {
+	return "order_cleanup"
+}
+
+// Run executes the cleanup task.
+func (t *OrderCleanupTask) Run(ctx context.Context) error {
+	cutoffTime := time.Now().Add(-t.olderThan)
+	t.logger.Printf("Starting order cleanup: removing orders older than %s", cutoffTime.Format(time.RFC3339))
+
+	// TODO: Implement cleanup logic
+	// This would require adding a method to repository like:
+	// DeleteOldCancelledOrders(ctx context.Context, olderThan time.Time) error
+
+	t.logger.Printf("Order cleanup completed (not yet implemented)")
+	return nil
+}
+
+// CleanupCompletedOrdersTask archives completed orders after a certain period.
+type CleanupCompletedOrdersTask struct {
+	orderRepo       order.Repository
+	logger          *log.Logger
+	retentionPeriod time.Duration
+}
+
+// NewCleanupCompletedOrdersTask creates a task to archive completed orders.
+func NewCleanupCompletedOrdersTask(orderRepo order.Repository, logger *log.Logger) *CleanupCompletedOrdersTask {
+	return &CleanupCompletedOrdersTask{
+		orderRepo:       orderRepo,
+		logger:          logger,
+		retentionPeriod: 90 * 24 * time.Hour, // 90 days
+	}
+}
+
+// Name returns the task name.
+func (t *CleanupCompletedOrdersTask) Name() string {
+	return "cleanup_completed_orders"
+}
+
+// Run executes the cleanup task.
+func (t *CleanupCompletedOrdersTask) Run(ctx context.Context) error {
+	t.logger.Printf("Starting cleanup of completed orders older than %v", t.retentionPeriod)
+
+	// TODO: Implement archiving logic
+	// - Find completed orders older than retention period
+	// - Archive them to separate table or storage
+	// - Delete from main orders table
+
+	t.logger.Printf("Completed orders cleanup finished (not yet implemented)")
+	return nil
+}
@@ -0,0 +1,46 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"log"
+)
+
+// PriceApplier applies scheduled price changes that have taken effect,
+// returning how many products changed. The product use case implements it,
+// invalidating the cached products and menus as it goes.
+type PriceApplier interface {
+	ApplyScheduledPrices(ctx context.Context) (int, error)
+}
+
+// PriceActivationTask activates scheduled product price changes.
+type PriceActivationTask struct {
+	prices PriceApplier
+	logger *log.Logger
+}
+
+// NewPriceActivationTask creates a task that activates pending price changes.
+func NewPriceActivationTask(prices PriceApplier, logger *log.Logger) *PriceActivationTask {
+	return &PriceActivationTask{
+		prices: prices,
+		logger: logger,
+	}
+}
+
+// Name returns the task name.
+func (t *PriceActivationTask) Name() string {
+	return "price_activation"
+}
+
+// Run executes the activation task.
+func (t *PriceActivationTask) Run(ctx context.Context) error {
+	activated, err := t.prices.ApplyScheduledPrices(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to activate price changes: %w", err)
+	}
+
+	if activated > 0 {
+		t.logger.Printf("Price activation: %d products repriced", activated)
+	}
+	return nil
+}
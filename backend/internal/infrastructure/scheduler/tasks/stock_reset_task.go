@@ -0,0 +1,44 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"log"
+)
+
+// StockResetter refills products sold on a daily quota, returning how many
+// were refilled. The product use case implements it, invalidating the cached
+// products and menus as it goes.
+type StockResetter interface {
+	ResetDailyStock(ctx context.Context) (int, error)
+}
+
+// DailyStockResetTask refills products sold on a daily quota.
+type DailyStockResetTask struct {
+	stock  StockResetter
+	logger *log.Logger
+}
+
+// NewDailyStockResetTask creates a task that resets daily product quotas.
+func NewDailyStockResetTask(stock StockResetter, logger *log.Logger) *DailyStockResetTask {
+	return &DailyStockResetTask{
+		stock:  stock,
+		logger: logger,
+	}
+}
+
+// Name returns the task name.
+func (t *DailyStockResetTask) Name() string {
+	return "daily_stock_reset"
+}
+
+// Run executes the reset task.
+func (t *DailyStockResetTask) Run(ctx context.Context) error {
+	reset, err := t.stock.ResetDailyStock(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to reset daily stock: %w", err)
+	}
+
+	t.logger.Printf("Daily stock reset: %d products refilled to their quota", reset)
+	return nil
+}
@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"fmt"
+
+	"foodie/backend/pkg/config"
+)
+
+// NewBlobStorage creates a blob storage instance based on configuration.
+// It reads from environment variables:
+//   - STORAGE_TYPE: "local" (default: "local")
+//   - STORAGE_LOCAL_DIR: directory blobs are written to (default: "./uploads")
+//   - STORAGE_PUBLIC_URL: URL prefix blobs are served under (default: "/media")
+func NewBlobStorage() (BlobStorage, error) {
+	storageType := config.Get("STORAGE_TYPE", "local")
+
+	switch storageType {
+	case "local":
+		return NewLocalStorage(
+			config.Get("STORAGE_LOCAL_DIR", "./uploads"),
+			config.Get("STORAGE_PUBLIC_URL", "/media"),
+		)
+	default:
+		return nil, fmt.Errorf("unsupported storage type: %s", storageType)
+	}
+}
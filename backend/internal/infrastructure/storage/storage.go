@@ -0,0 +1,19 @@
+package storage
+
+import (
+	"context"
+	"io"
+)
+
+// BlobStorage stores uploaded files and makes them reachable by URL.
+// This abstraction allows swapping implementations (local disk, S3, etc.)
+type BlobStorage interface {
+	// Put stores data under key, replacing any existing blob, and returns its public URL.
+	Put(ctx context.Context, key string, data io.Reader, contentType string) (string, error)
+
+	// Delete removes the blob stored under key. Deleting a missing blob is not an error.
+	Delete(ctx context.Context, key string) error
+
+	// KeyFromURL returns the key of a blob from the URL Put returned for it.
+	KeyFromURL(url string) (string, bool)
+}
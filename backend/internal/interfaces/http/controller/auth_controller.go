@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	authusecase "foodie/backend/internal/application/usecase/auth"
+	"foodie/backend/internal/domain/user"
+	"foodie/backend/internal/interfaces/http/dto"
+	httputils "foodie/backend/pkg/utils/http"
+)
+
+// AuthController handles HTTP requests for registration and sign-in.
+type AuthController struct {
+	authUseCase authusecase.UseCase
+}
+
+// NewAuthController creates a new auth controller.
+func NewAuthController(authUseCase authusecase.UseCase) *AuthController {
+	return &AuthController{
+		authUseCase: authUseCase,
+	}
+}
+
+// Register handles POST /api/v1/auth/register
+func (c *AuthController) Register(w http.ResponseWriter, r *http.Request) {
+	var req dto.RegisterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.BadRequest(w, "Invalid request body", err)
+		return
+	}
+
+	session, err := c.authUseCase.Register(r.Context(), authusecase.RegisterCommand{
+		Email:    req.Email,
+		Password: req.Password,
+		Name:     req.Name,
+		Role:     req.Role,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrEmailTaken):
+			httputils.Conflict(w, "Email is already registered", err)
+		case strings.Contains(err.Error(), "validation failed"):
+			httputils.BadRequest(w, "Validation failed", err)
+		default:
+			httputils.InternalServerError(w, "Failed to register", err)
+		}
+		return
+	}
+
+	httputils.Created(w, sessionToDTO(session, time.Now()))
+}
+
+// Login handles POST /api/v1/auth/login
+func (c *AuthController) Login(w http.ResponseWriter, r *http.Request) {
+	var req dto.LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.BadRequest(w, "Invalid request body", err)
+		return
+	}
+
+	session, err := c.authUseCase.Login(r.Context(), authusecase.LoginCommand{
+		Email:    req.Email,
+		Password: req.Password,
+	})
+	if err != nil {
+		if errors.Is(err, authusecase.ErrInvalidCredentials) {
+			httputils.Unauthorized(w, "Invalid email or password")
+			return
+		}
+		httputils.InternalServerError(w, "Failed to log in", err)
+		return
+	}
+
+	httputils.Success(w, sessionToDTO(session, time.Now()))
+}
+
+// sessionToDTO converts a session to the token response.
+func sessionToDTO(s *authusecase.Session, now time.Time) dto.AuthResponse {
+	return dto.AuthResponse{
+		AccessToken: s.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(s.ExpiresAt.Sub(now).Seconds()),
+		ExpiresAt:   s.ExpiresAt.Format(time.RFC3339),
+		User: dto.UserResponse{
+			ID:        s.User.ID,
+			Email:     s.User.Email,
+			Name:      s.User.Name,
+			Role:      string(s.User.Role),
+			CreatedAt: s.User.CreatedAt.Format(time.RFC3339),
+		},
+	}
+}
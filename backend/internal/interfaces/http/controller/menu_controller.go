@@ -0,0 +1,200 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	productusecase "foodie/backend/internal/application/usecase/product"
+	"foodie/backend/internal/domain/category"
+	"foodie/backend/internal/domain/product"
+	"foodie/backend/internal/domain/restaurant"
+	"foodie/backend/internal/interfaces/http/dto"
+	"foodie/backend/internal/interfaces/http/middleware"
+	httputils "foodie/backend/pkg/utils/http"
+)
+
+// MenuController handles HTTP requests for restaurant menus and their categories.
+type MenuController struct {
+	productUseCase productusecase.UseCase
+	products       *ProductController // Renders products the same way the product endpoints do
+}
+
+// NewMenuController creates a new menu controller.
+func NewMenuController(productUseCase productusecase.UseCase) *MenuController {
+	return &MenuController{
+		productUseCase: productUseCase,
+		products:       NewProductController(productUseCase),
+	}
+}
+
+// GetMenu handles GET /api/v1/restaurants/{id}/menu
+func (c *MenuController) GetMenu(w http.ResponseWriter, r *http.Request) {
+	restaurantID, ok := pathSegment(r, 3)
+	if !ok {
+		httputils.BadRequest(w, "Invalid restaurant ID", nil)
+		return
+	}
+
+	menu, err := c.productUseCase.GetMenu(r.Context(), restaurantID)
+	if err != nil {
+		c.writeCategoryError(w, err, "Failed to get menu")
+		return
+	}
+
+	resp := dto.MenuResponse{
+		RestaurantID:  menu.RestaurantID,
+		Categories:    make([]dto.MenuCategoryResponse, 0, len(menu.Sections)),
+		Uncategorized: c.productsToDTO(menu.Uncategorized),
+	}
+	for _, section := range menu.Sections {
+		resp.Categories = append(resp.Categories, dto.MenuCategoryResponse{
+			CategoryResponse: categoryToDTO(&section.Category),
+			Products:         c.productsToDTO(section.Products),
+		})
+	}
+	httputils.Success(w, resp)
+}
+
+// CreateCategory handles POST /api/v1/restaurants/{id}/categories
+func (c *MenuController) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	restaurantID, ok := pathSegment(r, 3)
+	if !ok {
+		httputils.BadRequest(w, "Invalid restaurant ID", nil)
+		return
+	}
+
+	var req dto.CategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.BadRequest(w, "Invalid request body", err)
+		return
+	}
+	availability, err := availabilityFromDTO(req.Availability)
+	if err != nil {
+		httputils.BadRequest(w, "Validation failed", err)
+		return
+	}
+
+	created, err := c.productUseCase.CreateCategory(r.Context(), productusecase.CreateCategoryCommand{
+		ActorID:      middleware.GetUserID(r),
+		ActorRole:    middleware.GetUserRole(r),
+		RestaurantID: restaurantID,
+		Name:         req.Name,
+		SortOrder:    req.SortOrder,
+		Visible:      req.Visible == nil || *req.Visible,
+		Availability: availability,
+	})
+	if err != nil {
+		c.writeCategoryError(w, err, "Failed to create category")
+		return
+	}
+
+	httputils.Created(w, categoryToDTO(created))
+}
+
+// UpdateCategory handles PUT /api/v1/categories/{id}
+func (c *MenuController) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	categoryID, ok := pathSegment(r, 3)
+	if !ok {
+		httputils.BadRequest(w, "Invalid category ID", nil)
+		return
+	}
+
+	var req dto.CategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.BadRequest(w, "Invalid request body", err)
+		return
+	}
+	availability, err := availabilityFromDTO(req.Availability)
+	if err != nil {
+		httputils.BadRequest(w, "Validation failed", err)
+		return
+	}
+
+	updated, err := c.productUseCase.UpdateCategory(r.Context(), productusecase.UpdateCategoryCommand{
+		CategoryID:   categoryID,
+		ActorID:      middleware.GetUserID(r),
+		ActorRole:    middleware.GetUserRole(r),
+		Name:         req.Name,
+		SortOrder:    req.SortOrder,
+		Visible:      req.Visible == nil || *req.Visible,
+		Availability: availability,
+	})
+	if err != nil {
+		c.writeCategoryError(w, err, "Failed to update category")
+		return
+	}
+
+	httputils.Success(w, categoryToDTO(updated))
+}
+
+// DeleteCategory handles DELETE /api/v1/categories/{id}
+// The category's products are kept and listed as uncategorized.
+func (c *MenuController) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	categoryID, ok := pathSegment(r, 3)
+	if !ok {
+		httputils.BadRequest(w, "Invalid category ID", nil)
+		return
+	}
+
+	err := c.productUseCase.DeleteCategory(r.Context(), productusecase.DeleteCategoryCommand{
+		CategoryID: categoryID,
+		ActorID:    middleware.GetUserID(r),
+		ActorRole:  middleware.GetUserRole(r),
+	})
+	if err != nil {
+		c.writeCategoryError(w, err, "Failed to delete category")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeCategoryError maps menu and category failures to HTTP responses.
+func (c *MenuController) writeCategoryError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, category.ErrNotFound):
+		httputils.NotFound(w, "Category not found")
+	case errors.Is(err, restaurant.ErrNotOwner):
+		httputils.Forbidden(w, "Restaurant does not belong to user")
+	case strings.Contains(err.Error(), "validation failed"):
+		httputils.BadRequest(w, "Validation failed", err)
+	default:
+		httputils.InternalServerError(w, message, err)
+	}
+}
+
+// pathSegment returns the non-empty path segment at index, e.g. the ID in
+// /api/v1/categories/{id} is at index 3.
+func pathSegment(r *http.Request, index int) (string, bool) {
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) <= index || pathParts[index] == "" {
+		return "", false
+	}
+	return pathParts[index], true
+}
+
+// productsToDTO converts menu products; an empty section renders as [].
+func (c *MenuController) productsToDTO(products []product.Product) []dto.ProductResponse {
+	result := make([]dto.ProductResponse, 0, len(products))
+	for i := range products {
+		result = append(result, c.products.productToDTO(&products[i]))
+	}
+	return result
+}
+
+// categoryToDTO converts a domain Category entity to DTO.
+func categoryToDTO(c *category.Category) dto.CategoryResponse {
+	return dto.CategoryResponse{
+		ID:           c.ID,
+		RestaurantID: c.RestaurantID,
+		Name:         c.Name,
+		SortOrder:    c.SortOrder,
+		Visible:      c.Visible,
+		Availability: availabilityToDTO(c.Availability),
+		CreatedAt:    c.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    c.UpdatedAt.Format(time.RFC3339),
+	}
+}
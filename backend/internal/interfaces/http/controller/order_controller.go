@@ -2,13 +2,19 @@ package controller
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strings"
 	"time"
 
 	orderusecase "foodie/backend/internal/application/usecase/order"
 	"foodie/backend/internal/domain/order"
+	"foodie/backend/internal/domain/product"
+	"foodie/backend/internal/domain/restaurant"
 	"foodie/backend/internal/interfaces/http/dto"
+	"foodie/backend/internal/interfaces/http/middleware"
 	httputils "foodie/backend/pkg/utils/http"
 	"foodie/backend/pkg/utils/pagination"
 )
@@ -35,33 +41,39 @@ func (c *OrderController) CreateOrder(w http.ResponseWriter, r *http.Request) {
 
 	// Convert DTO to use case command
 	cmd := orderusecase.CreateOrderCommand{
-		UserID:          req.UserID,
+		UserID:          middleware.GetUserID(r),
 		RestaurantID:    req.RestaurantID,
 		PaymentMethod:   req.PaymentMethod,
 		DeliveryAddress: req.DeliveryAddress,
-	}
-
-	// Convert order items
-	for _, item := range req.Items {
-		cmd.Items = append(cmd.Items, orderusecase.OrderItemCommand{
-			ProductID: item.ProductID,
-			Quantity:  item.Quantity,
-		})
+		PromoCode:       req.PromoCode,
+		QuoteID:         req.QuoteID,
+		Items:           itemsToCommands(req.Items),
 	}
 
 	// Call use case
 	createdOrder, err := c.orderUseCase.CreateOrder(r.Context(), cmd)
 	if err != nil {
 		// Check error type and return appropriate status code
-		if strings.Contains(err.Error(), "validation failed") {
-			httputils.BadRequest(w, "Validation failed", err)
+		// The declined order is kept in payment_failed; return it so the
+		// client can cancel it or find it again
+		var paymentErr *orderusecase.PaymentFailedError
+		if errors.As(err, &paymentErr) {
+			var declined interface{}
+			if createdOrder != nil {
+				declined = c.orderToDTO(createdOrder)
+			}
+			httputils.ErrorWithData(w, http.StatusPaymentRequired, "Payment authorization failed", err, declined)
 			return
 		}
-		if strings.Contains(err.Error(), "not found") {
-			httputils.NotFound(w, "Product not found")
+		if errors.Is(err, orderusecase.ErrQuoteExpired) {
+			httputils.Error(w, http.StatusUnprocessableEntity, "Quote has expired, request a new one", err)
+			return
+		}
+		if errors.Is(err, orderusecase.ErrInvalidQuote) {
+			httputils.Error(w, http.StatusUnprocessableEntity, "Invalid quote", err)
 			return
 		}
-		httputils.InternalServerError(w, "Failed to create order", err)
+		c.writePricingError(w, err, "Failed to create order")
 		return
 	}
 
@@ -71,6 +83,57 @@ func (c *OrderController) CreateOrder(w http.ResponseWriter, r *http.Request) {
 	httputils.Created(w, response)
 }
 
+// QuoteOrder handles POST /api/v1/orders/quote
+func (c *OrderController) QuoteOrder(w http.ResponseWriter, r *http.Request) {
+	var req dto.QuoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.BadRequest(w, "Invalid request body", err)
+		return
+	}
+
+	cmd := orderusecase.QuoteCommand{
+		RestaurantID:    req.RestaurantID,
+		DeliveryAddress: req.DeliveryAddress,
+		PromoCode:       req.PromoCode,
+		Items:           itemsToCommands(req.Items),
+	}
+
+	quote, err := c.orderUseCase.QuoteOrder(r.Context(), cmd)
+	if err != nil {
+		c.writePricingError(w, err, "Failed to quote order")
+		return
+	}
+
+	httputils.Success(w, c.quoteToDTO(quote))
+}
+
+// writePricingError maps errors raised while pricing a basket to HTTP responses.
+func (c *OrderController) writePricingError(w http.ResponseWriter, err error, message string) {
+	var rangeErr *order.OutOfDeliveryRangeError
+	if errors.As(err, &rangeErr) {
+		httputils.Error(w, http.StatusUnprocessableEntity, "Delivery address is outside the delivery area", err)
+		return
+	}
+	var stockErr *product.OutOfStockError
+	if errors.As(err, &stockErr) {
+		httputils.Conflict(w, "Product is sold out", err)
+		return
+	}
+	if errors.Is(err, restaurant.ErrNotFound) {
+		httputils.NotFound(w, "Restaurant not found")
+		return
+	}
+	if strings.Contains(err.Error(), "validation failed") {
+		httputils.BadRequest(w, "Validation failed", err)
+		return
+	}
+	if strings.Contains(err.Error(), "not found") {
+		httputils.NotFound(w, "Product not found")
+		return
+	}
+	httputils.InternalServerError(w, message, err)
+}
+
 // GetOrder handles GET /api/v1/orders/{id}
 func (c *OrderController) GetOrder(w http.ResponseWriter, r *http.Request) {
 	// Extract ID from path: /api/v1/orders/{id}
@@ -82,42 +145,244 @@ func (c *OrderController) GetOrder(w http.ResponseWriter, r *http.Request) {
 	orderID := pathParts[3]
 
 	// Call use case
-	o, err := c.orderUseCase.GetOrder(r.Context(), orderID)
+	query := orderusecase.OrderQuery{
+		OrderID:   orderID,
+		ActorID:   middleware.GetUserID(r),
+		ActorRole: middleware.GetUserRole(r),
+	}
+	o, err := c.orderUseCase.GetOrder(r.Context(), query)
 	if err != nil {
+		writeOrderAccessError(w, err, "Failed to get order")
+		return
+	}
+
+	// Convert to DTO and respond
+	response := c.orderToDTO(o)
+
+	// Optionally embed the status timeline: ?include=timeline
+	if includes(r.URL.Query().Get("include"), "timeline") {
+		history, err := c.orderUseCase.GetOrderTimeline(r.Context(), query)
+		if err != nil {
+			httputils.InternalServerError(w, "Failed to get order timeline", err)
+			return
+		}
+		response.Timeline = c.timelineToDTO(history)
+	}
+
+	httputils.Success(w, response)
+}
+
+// GetOrderTimeline handles GET /api/v1/orders/{id}/timeline
+func (c *OrderController) GetOrderTimeline(w http.ResponseWriter, r *http.Request) {
+	// Extract ID from path: /api/v1/orders/{id}/timeline
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 5 || pathParts[3] == "" {
+		httputils.BadRequest(w, "Invalid order ID", nil)
+		return
+	}
+	orderID := pathParts[3]
+
+	history, err := c.orderUseCase.GetOrderTimeline(r.Context(), orderusecase.OrderQuery{
+		OrderID:   orderID,
+		ActorID:   middleware.GetUserID(r),
+		ActorRole: middleware.GetUserRole(r),
+	})
+	if err != nil {
+		writeOrderAccessError(w, err, "Failed to get order timeline")
+		return
+	}
+
+	httputils.Success(w, dto.OrderTimelineResponse{
+		OrderID:  orderID,
+		Timeline: c.timelineToDTO(history),
+	})
+}
+
+// UpdateOrderStatus handles PATCH /api/v1/orders/{id}/status
+func (c *OrderController) UpdateOrderStatus(w http.ResponseWriter, r *http.Request) {
+	// Extract ID from path: /api/v1/orders/{id}/status
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 5 || pathParts[3] == "" {
+		httputils.BadRequest(w, "Invalid order ID", nil)
+		return
+	}
+	orderID := pathParts[3]
+
+	var req dto.UpdateOrderStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.BadRequest(w, "Invalid request body", err)
+		return
+	}
+
+	// Call use case
+	updatedOrder, err := c.orderUseCase.UpdateStatus(r.Context(), orderusecase.UpdateStatusCommand{
+		OrderID:   orderID,
+		Status:    req.Status,
+		ActorID:   middleware.GetUserID(r),
+		ActorRole: middleware.GetUserRole(r),
+		Note:      req.Note,
+	})
+	if err != nil {
+		var transitionErr *order.InvalidTransitionError
+		if errors.As(err, &transitionErr) {
+			httputils.Conflict(w, "Invalid status transition", err)
+			return
+		}
+		if errors.Is(err, order.ErrStatusChanged) {
+			httputils.Conflict(w, "Order status was changed by another request", err)
+			return
+		}
+		if errors.Is(err, order.ErrNotOrderOwner) {
+			httputils.Forbidden(w, "Only the order's restaurant or an admin can change its status")
+			return
+		}
+		if errors.Is(err, restaurant.ErrNotOwner) {
+			httputils.Forbidden(w, "Restaurant does not belong to user")
+			return
+		}
+		if strings.Contains(err.Error(), "validation failed") {
+			httputils.BadRequest(w, "Validation failed", err)
+			return
+		}
 		if strings.Contains(err.Error(), "not found") {
 			httputils.NotFound(w, "Order not found")
 			return
 		}
-		httputils.InternalServerError(w, "Failed to get order", err)
+		if strings.Contains(err.Error(), "payment capture failed") {
+			httputils.Error(w, http.StatusBadGateway, "Payment capture failed", err)
+			return
+		}
+		httputils.InternalServerError(w, "Failed to update order status", err)
 		return
 	}
 
-	// Convert to DTO and respond
-	response := c.orderToDTO(o)
-	httputils.Success(w, response)
+	httputils.Success(w, c.orderToDTO(updatedOrder))
+}
+
+// CancelOrder handles POST /api/v1/orders/{id}/cancel
+func (c *OrderController) CancelOrder(w http.ResponseWriter, r *http.Request) {
+	// Extract ID from path: /api/v1/orders/{id}/cancel
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 5 || pathParts[3] == "" {
+		httputils.BadRequest(w, "Invalid order ID", nil)
+		return
+	}
+	orderID := pathParts[3]
+
+	var req dto.CancelOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.BadRequest(w, "Invalid request body", err)
+		return
+	}
+
+	// Call use case on behalf of the authenticated caller
+	cancelledOrder, err := c.orderUseCase.CancelOrder(r.Context(), orderusecase.CancelOrderCommand{
+		OrderID:   orderID,
+		ActorID:   middleware.GetUserID(r),
+		ActorRole: middleware.GetUserRole(r),
+		Reason:    req.Reason,
+		Note:      req.Note,
+	})
+	if err != nil {
+		var notAllowedErr *order.CancellationNotAllowedError
+		var transitionErr *order.InvalidTransitionError
+		switch {
+		case errors.As(err, &notAllowedErr), errors.As(err, &transitionErr):
+			httputils.Conflict(w, "Order cannot be cancelled", err)
+		case errors.Is(err, order.ErrStatusChanged):
+			httputils.Conflict(w, "Order status was changed by another request", err)
+		case errors.Is(err, order.ErrNotOrderOwner):
+			httputils.Forbidden(w, "Order does not belong to user")
+		case errors.Is(err, restaurant.ErrNotOwner):
+			httputils.Forbidden(w, "Restaurant does not belong to user")
+		case strings.Contains(err.Error(), "validation failed"):
+			httputils.BadRequest(w, "Validation failed", err)
+		case strings.Contains(err.Error(), "not found"):
+			httputils.NotFound(w, "Order not found")
+		case strings.Contains(err.Error(), "refund failed"):
+			httputils.Error(w, http.StatusBadGateway, "Refund failed", err)
+		default:
+			httputils.InternalServerError(w, "Failed to cancel order", err)
+		}
+		return
+	}
+
+	httputils.Success(w, c.orderToDTO(cancelledOrder))
 }
 
 // ListOrders handles GET /api/v1/orders
 func (c *OrderController) ListOrders(w http.ResponseWriter, r *http.Request) {
+	c.listOrders(w, r, r.URL.Query().Get("restaurant_id"))
+}
+
+// ListRestaurantOrders handles GET /api/v1/restaurants/{id}/orders
+// It lists a restaurant's orders (e.g. ?status=pending for the incoming queue)
+// and accepts the same filters as ListOrders.
+func (c *OrderController) ListRestaurantOrders(w http.ResponseWriter, r *http.Request) {
+	// Extract ID from path: /api/v1/restaurants/{id}/orders
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 5 || pathParts[3] == "" {
+		httputils.BadRequest(w, "Invalid restaurant ID", nil)
+		return
+	}
+	c.listOrders(w, r, pathParts[3])
+}
+
+// listOrders lists orders matching the request's query filters, scoped to restaurantID if set.
+func (c *OrderController) listOrders(w http.ResponseWriter, r *http.Request, restaurantID string) {
 	// Parse query parameters
 	page := pagination.ParsePage(r.URL.Query().Get("page"))
 	offset := pagination.ParseOffset(r.URL.Query().Get("offset"))
 	limit := pagination.ParseLimit(r.URL.Query().Get("limit"), 20, 1, 100)
 
 	// Convert DTO request to use case request
+	query := r.URL.Query()
 	useCaseReq := orderusecase.ListOrdersRequest{
-		UserID: r.URL.Query().Get("user_id"),
-		Status: r.URL.Query().Get("status"),
-		Page:   page,
-		Offset: offset,
-		Limit:  limit,
+		ActorID:       middleware.GetUserID(r),
+		ActorRole:     middleware.GetUserRole(r),
+		UserID:        query.Get("user_id"),
+		RestaurantID:  restaurantID,
+		PaymentMethod: query.Get("payment_method"),
+		MinTotal:      query.Get("min_total"),
+		MaxTotal:      query.Get("max_total"),
+		Sort:          query.Get("sort"),
+		Page:          page,
+		Offset:        offset,
+		Limit:         limit,
+	}
+
+	// status may be repeated (?status=a&status=b) or comma-separated (?status=a,b)
+	for _, value := range query["status"] {
+		for _, status := range strings.Split(value, ",") {
+			if status = strings.TrimSpace(status); status != "" {
+				useCaseReq.Statuses = append(useCaseReq.Statuses, status)
+			}
+		}
+	}
+
+	var err error
+	if useCaseReq.CreatedFrom, err = parseTimeParam(query.Get("created_from")); err != nil {
+		httputils.BadRequest(w, "Invalid created_from", err)
+		return
+	}
+	if useCaseReq.CreatedTo, err = parseTimeParam(query.Get("created_to")); err != nil {
+		httputils.BadRequest(w, "Invalid created_to", err)
+		return
 	}
 
 	// Call use case
 	orders, total, err := c.orderUseCase.ListOrders(r.Context(), useCaseReq)
 	if err != nil {
-		if strings.Contains(err.Error(), "required") {
-			httputils.BadRequest(w, "user_id filter is required", err)
+		if errors.Is(err, order.ErrNotOrderOwner) {
+			httputils.Forbidden(w, "You can only list your own orders")
+			return
+		}
+		if errors.Is(err, restaurant.ErrNotOwner) {
+			httputils.Forbidden(w, "Restaurant does not belong to user")
+			return
+		}
+		if strings.Contains(err.Error(), "validation failed") {
+			httputils.BadRequest(w, "Validation failed", err)
 			return
 		}
 		httputils.InternalServerError(w, "Failed to list orders", err)
@@ -154,24 +419,287 @@ func (c *OrderController) ListOrders(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// AcceptOrder handles POST /api/v1/restaurants/{id}/orders/{orderId}/accept
+func (c *OrderController) AcceptOrder(w http.ResponseWriter, r *http.Request) {
+	restaurantID, orderID, ok := restaurantOrderPath(r)
+	if !ok {
+		httputils.BadRequest(w, "Invalid restaurant or order ID", nil)
+		return
+	}
+
+	// Body is optional; an empty body accepts with the default preparation time
+	var req dto.AcceptOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		httputils.BadRequest(w, "Invalid request body", err)
+		return
+	}
+
+	acceptedOrder, err := c.orderUseCase.AcceptOrder(r.Context(), orderusecase.AcceptOrderCommand{
+		RestaurantID:    restaurantID,
+		OrderID:         orderID,
+		ActorID:         middleware.GetUserID(r),
+		ActorRole:       middleware.GetUserRole(r),
+		PrepTimeMinutes: req.PrepTimeMinutes,
+	})
+	if err != nil {
+		c.writeRestaurantOrderError(w, err, "Order cannot be accepted", "Failed to accept order")
+		return
+	}
+
+	httputils.Success(w, c.orderToDTO(acceptedOrder))
+}
+
+// RejectOrder handles POST /api/v1/restaurants/{id}/orders/{orderId}/reject
+// The order is cancelled and any payment taken is refunded.
+func (c *OrderController) RejectOrder(w http.ResponseWriter, r *http.Request) {
+	restaurantID, orderID, ok := restaurantOrderPath(r)
+	if !ok {
+		httputils.BadRequest(w, "Invalid restaurant or order ID", nil)
+		return
+	}
+
+	var req dto.RejectOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		httputils.BadRequest(w, "Invalid request body", err)
+		return
+	}
+
+	rejectedOrder, err := c.orderUseCase.RejectOrder(r.Context(), orderusecase.RejectOrderCommand{
+		RestaurantID: restaurantID,
+		OrderID:      orderID,
+		ActorID:      middleware.GetUserID(r),
+		ActorRole:    middleware.GetUserRole(r),
+		Reason:       req.Reason,
+		Note:         req.Note,
+	})
+	if err != nil {
+		c.writeRestaurantOrderError(w, err, "Order cannot be rejected", "Failed to reject order")
+		return
+	}
+
+	httputils.Success(w, c.orderToDTO(rejectedOrder))
+}
+
+// writeRestaurantOrderError maps accept/reject failures to HTTP responses.
+func (c *OrderController) writeRestaurantOrderError(w http.ResponseWriter, err error, conflictMessage, message string) {
+	var notAllowedErr *order.CancellationNotAllowedError
+	var transitionErr *order.InvalidTransitionError
+	switch {
+	case errors.As(err, &notAllowedErr), errors.As(err, &transitionErr):
+		httputils.Conflict(w, conflictMessage, err)
+	case errors.Is(err, order.ErrStatusChanged):
+		httputils.Conflict(w, "Order status was changed by another request", err)
+	case errors.Is(err, restaurant.ErrNotOwner):
+		httputils.Forbidden(w, "Restaurant does not belong to user")
+	case strings.Contains(err.Error(), "validation failed"):
+		httputils.BadRequest(w, "Validation failed", err)
+	case strings.Contains(err.Error(), "not found"):
+		httputils.NotFound(w, "Order not found")
+	case strings.Contains(err.Error(), "refund failed"):
+		httputils.Error(w, http.StatusBadGateway, "Refund failed", err)
+	default:
+		httputils.InternalServerError(w, message, err)
+	}
+}
+
+// writeOrderAccessError maps failures to read an order to HTTP responses.
+func writeOrderAccessError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, order.ErrNotOrderOwner):
+		httputils.Forbidden(w, "Order does not belong to user")
+	case errors.Is(err, restaurant.ErrNotOwner):
+		httputils.Forbidden(w, "Restaurant does not belong to user")
+	case strings.Contains(err.Error(), "not found"):
+		httputils.NotFound(w, "Order not found")
+	default:
+		httputils.InternalServerError(w, message, err)
+	}
+}
+
+// restaurantOrderPath extracts IDs from /api/v1/restaurants/{id}/orders/{orderId}/{action}
+func restaurantOrderPath(r *http.Request) (restaurantID, orderID string, ok bool) {
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 7 || pathParts[3] == "" || pathParts[5] == "" {
+		return "", "", false
+	}
+	return pathParts[3], pathParts[5], true
+}
+
+// itemsToCommands converts requested order items to use case commands.
+func itemsToCommands(items []dto.OrderItemRequest) []orderusecase.OrderItemCommand {
+	cmds := make([]orderusecase.OrderItemCommand, 0, len(items))
+	for _, item := range items {
+		cmd := orderusecase.OrderItemCommand{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		}
+		for _, m := range item.Modifiers {
+			cmd.Modifiers = append(cmd.Modifiers, orderusecase.ModifierCommand{GroupID: m.GroupID, OptionID: m.OptionID})
+		}
+		cmds = append(cmds, cmd)
+	}
+	return cmds
+}
+
+// itemToDTO converts an order item, with its chosen modifiers, to DTO.
+func itemToDTO(item order.OrderItem) dto.OrderItemResponse {
+	resp := dto.OrderItemResponse{
+		ProductID:   item.ProductID,
+		ProductName: item.ProductName,
+		Quantity:    item.Quantity,
+		Price:       item.Price,
+		UnitPrice:   item.UnitPrice(),
+	}
+	for _, m := range item.Modifiers {
+		resp.Modifiers = append(resp.Modifiers, dto.ItemModifierResponse{
+			GroupID:    m.GroupID,
+			GroupName:  m.GroupName,
+			OptionID:   m.OptionID,
+			OptionName: m.OptionName,
+			PriceDelta: m.PriceDelta,
+		})
+	}
+	return resp
+}
+
+// itemLabel names an item with its chosen options, e.g. "Pizza (Large, Extra cheese)".
+func itemLabel(item order.OrderItem) string {
+	if len(item.Modifiers) == 0 {
+		return item.ProductName
+	}
+	options := make([]string, 0, len(item.Modifiers))
+	for _, m := range item.Modifiers {
+		options = append(options, m.OptionName)
+	}
+	return item.ProductName + " (" + strings.Join(options, ", ") + ")"
+}
+
 // orderToDTO converts domain Order entity to DTO.
 func (c *OrderController) orderToDTO(o *order.Order) dto.OrderResponse {
 	items := make([]dto.OrderItemResponse, 0, len(o.Items))
 	for _, item := range o.Items {
-		items = append(items, dto.OrderItemResponse{
-			ProductID:   item.ProductID,
-			ProductName: item.ProductName,
-			Quantity:    item.Quantity,
-			Price:       item.Price,
-		})
+		items = append(items, itemToDTO(item))
+	}
+
+	var cancellation *dto.CancellationResponse
+	if o.Cancellation != nil {
+		cancellation = &dto.CancellationResponse{
+			CancelledBy: o.Cancellation.By,
+			Role:        string(o.Cancellation.Role),
+			Reason:      string(o.Cancellation.Reason),
+			Note:        o.Cancellation.Note,
+			CancelledAt: o.Cancellation.At.Format(time.RFC3339),
+		}
+	}
+
+	var estimatedDeliveryAt string
+	if o.EstimatedDeliveryAt != nil {
+		estimatedDeliveryAt = o.EstimatedDeliveryAt.Format(time.RFC3339)
 	}
 
 	return dto.OrderResponse{
-		ID:        o.ID,
-		UserID:    o.UserID,
-		Status:    string(o.Status),
-		Total:     o.Total,
-		CreatedAt: o.CreatedAt.Format(time.RFC3339),
-		Items:     items,
+		ID:                  o.ID,
+		UserID:              o.UserID,
+		RestaurantID:        o.RestaurantID,
+		Status:              string(o.Status),
+		Total:               o.Total,
+		Subtotal:            o.Subtotal,
+		Discount:            o.Discount,
+		ServiceFee:          o.ServiceFee,
+		Tax:                 o.Tax,
+		PromoCode:           o.PromoCode,
+		PaymentStatus:       string(o.PaymentStatus),
+		DeliveryFee:         o.DeliveryFee,
+		DistanceKm:          o.DistanceKm,
+		PrepTimeMinutes:     o.PrepTimeMinutes,
+		EstimatedDeliveryAt: estimatedDeliveryAt,
+		Cancellation:        cancellation,
+		CreatedAt:           o.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:           o.UpdatedAt.Format(time.RFC3339),
+		Items:               items,
+	}
+}
+
+// quoteToDTO converts a quote to its DTO, itemising every line of the total.
+func (c *OrderController) quoteToDTO(q *orderusecase.Quote) dto.QuoteResponse {
+	items := make([]dto.OrderItemResponse, 0, len(q.Items))
+	lines := make([]dto.QuoteLineResponse, 0, len(q.Items)+4)
+	for _, item := range q.Items {
+		items = append(items, itemToDTO(item))
+		lines = append(lines, dto.QuoteLineResponse{
+			Type:   "item",
+			Label:  fmt.Sprintf("%d x %s", item.Quantity, itemLabel(item)),
+			Amount: item.LineTotal(),
+		})
+	}
+
+	p := q.Pricing
+	if p.Discount.IsPositive() {
+		lines = append(lines, dto.QuoteLineResponse{Type: "discount", Label: "Promo " + q.PromoCode, Amount: p.Discount.Neg()})
+	}
+	lines = append(lines, dto.QuoteLineResponse{Type: "delivery_fee", Label: fmt.Sprintf("Delivery (%.1f km)", q.DistanceKm), Amount: p.DeliveryFee})
+	if p.ServiceFee.IsPositive() {
+		lines = append(lines, dto.QuoteLineResponse{Type: "service_fee", Label: "Service fee", Amount: p.ServiceFee})
+	}
+	if p.Tax.IsPositive() {
+		lines = append(lines, dto.QuoteLineResponse{Type: "tax", Label: "Tax", Amount: p.Tax})
+	}
+
+	return dto.QuoteResponse{
+		QuoteID:             q.ID,
+		ExpiresAt:           q.ExpiresAt.Format(time.RFC3339),
+		RestaurantID:        q.RestaurantID,
+		Items:               items,
+		Lines:               lines,
+		Subtotal:            p.Subtotal,
+		Discount:            p.Discount,
+		DeliveryFee:         p.DeliveryFee,
+		ServiceFee:          p.ServiceFee,
+		Tax:                 p.Tax,
+		Total:               p.Total,
+		PromoCode:           q.PromoCode,
+		DistanceKm:          q.DistanceKm,
+		EstimatedDeliveryAt: q.EstimatedDeliveryAt.Format(time.RFC3339),
+	}
+}
+
+// timelineToDTO converts an order's status history to DTOs.
+func (c *OrderController) timelineToDTO(history []order.StatusChange) []dto.StatusChangeResponse {
+	timeline := make([]dto.StatusChangeResponse, 0, len(history))
+	for _, change := range history {
+		timeline = append(timeline, dto.StatusChangeResponse{
+			FromStatus: string(change.FromStatus),
+			ToStatus:   string(change.ToStatus),
+			ActorID:    change.ActorID,
+			ActorRole:  string(change.ActorRole),
+			Note:       change.Note,
+			ChangedAt:  change.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return timeline
+}
+
+// parseTimeParam parses an RFC3339 timestamp or a YYYY-MM-DD date (midnight UTC).
+// An empty value returns nil.
+func parseTimeParam(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		if t, err = time.Parse(time.DateOnly, value); err != nil {
+			return nil, err
+		}
+	}
+	return &t, nil
+}
+
+// includes reports whether a comma-separated ?include= value lists name.
+func includes(include, name string) bool {
+	for _, part := range strings.Split(include, ",") {
+		if strings.TrimSpace(part) == name {
+			return true
+		}
 	}
+	return false
 }
@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	orderusecase "foodie/backend/internal/application/usecase/order"
+	"foodie/backend/internal/domain/order"
+)
+
+// stubOrderUseCase fails every status change with err.
+type stubOrderUseCase struct {
+	orderusecase.UseCase
+	err error
+}
+
+func (s stubOrderUseCase) UpdateStatus(ctx context.Context, cmd orderusecase.UpdateStatusCommand) (*order.Order, error) {
+	return nil, s.err
+}
+
+func (s stubOrderUseCase) CancelOrder(ctx context.Context, cmd orderusecase.CancelOrderCommand) (*order.Order, error) {
+	return nil, s.err
+}
+
+func TestStatusChangeErrorsMapToConflict(t *testing.T) {
+	transitionErr := &order.InvalidTransitionError{From: order.StatusCompleted, To: order.StatusPreparing}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "invalid transition", err: transitionErr, wantStatus: http.StatusConflict},
+		{name: "wrapped invalid transition", err: fmt.Errorf("update order: %w", transitionErr), wantStatus: http.StatusConflict},
+		{name: "status changed concurrently", err: order.ErrStatusChanged, wantStatus: http.StatusConflict},
+		{name: "not the order's restaurant", err: order.ErrNotOrderOwner, wantStatus: http.StatusForbidden},
+		{name: "validation", err: fmt.Errorf("validation failed: unknown status"), wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewOrderController(stubOrderUseCase{err: tt.err})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/orders/o1/status", strings.NewReader(`{"status":"preparing"}`))
+			c.UpdateOrderStatus(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("UpdateOrderStatus: status %d, want %d", rec.Code, tt.wantStatus)
+			}
+
+			rec = httptest.NewRecorder()
+			req = httptest.NewRequest(http.MethodPost, "/api/v1/orders/o1/cancel", strings.NewReader(`{"reason":"customer_request"}`))
+			c.CancelOrder(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("CancelOrder: status %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -1,14 +1,25 @@
 package controller
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	productusecase "foodie/backend/internal/application/usecase/product"
 	"foodie/backend/internal/domain/product"
+	"foodie/backend/internal/domain/restaurant"
 	"foodie/backend/internal/interfaces/http/dto"
+	"foodie/backend/internal/interfaces/http/middleware"
 	httputils "foodie/backend/pkg/utils/http"
 	"foodie/backend/pkg/utils/pagination"
+	timeutils "foodie/backend/pkg/utils/time"
 )
 
 // ProductController handles HTTP requests for product operations.
@@ -30,29 +41,53 @@ func (c *ProductController) ListProducts(w http.ResponseWriter, r *http.Request)
 	offset := pagination.ParseOffset(r.URL.Query().Get("offset"))
 	limit := pagination.ParseLimit(r.URL.Query().Get("limit"), 20, 1, 100)
 
-	// Calculate actual offset used (may be calculated from page)
+	// Calculate actual offset used (may be calculated from page).
+	// An explicit offset wins, and the reported page is derived from it.
 	actualOffset := offset
 	if actualOffset == 0 {
 		actualOffset = pagination.CalculateOffset(page, limit)
+	} else {
+		page = pagination.CalculatePageFromOffset(offset, limit)
 	}
 
 	// Convert DTO request to use case request
+	query := r.URL.Query()
+	includeUnavailable := false
+	if raw := query.Get("include_unavailable"); raw != "" {
+		var err error
+		if includeUnavailable, err = strconv.ParseBool(raw); err != nil {
+			httputils.BadRequest(w, "Invalid include_unavailable", err)
+			return
+		}
+	}
 	useCaseReq := productusecase.ListProductsRequest{
-		RestaurantID: r.URL.Query().Get("restaurant_id"),
-		Page:         page,
-		Offset:       actualOffset,
-		Limit:        limit,
+		RestaurantID:       query.Get("restaurant_id"),
+		CategoryID:         query.Get("category_id"),
+		Name:               query.Get("name"),
+		MinPrice:           query.Get("min_price"),
+		MaxPrice:           query.Get("max_price"),
+		Sort:               query.Get("sort"),
+		Page:               page,
+		Offset:             actualOffset,
+		Limit:              limit,
+		IncludeUnavailable: includeUnavailable,
 	}
 
 	// Call use case
 	products, total, err := c.productUseCase.ListProducts(r.Context(), useCaseReq)
 	if err != nil {
+		if strings.Contains(err.Error(), "validation failed") {
+			httputils.BadRequest(w, "Validation failed", err)
+			return
+		}
 		httputils.InternalServerError(w, "Failed to list products", err)
 		return
 	}
 
-	// Calculate pagination metadata
+	// Calculate pagination metadata; has_next must hold for offsets that are not page-aligned too
 	paginationMeta := pagination.CalculateMeta(page, limit, total)
+	paginationMeta.HasNext = actualOffset+len(products) < total
+	paginationMeta.HasPrev = actualOffset > 0
 
 	// Convert domain entities to DTOs
 	productDTOs := make([]dto.ProductResponse, 0, len(products))
@@ -75,13 +110,450 @@ func (c *ProductController) ListProducts(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+// GetProduct handles GET /api/v1/products/{id}
+// Responses carry an ETag, so clients can revalidate with If-None-Match and
+// get 304 Not Modified. There is no Last-Modified: stock and scheduled price
+// changes do not bump updated_at, so a date would report stale data as fresh.
+func (c *ProductController) GetProduct(w http.ResponseWriter, r *http.Request) {
+	productID, ok := productIDFromPath(r)
+	if !ok {
+		httputils.BadRequest(w, "Invalid product ID", nil)
+		return
+	}
+
+	p, err := c.productUseCase.GetProduct(r.Context(), productID)
+	if err != nil {
+		if errors.Is(err, product.ErrNotFound) {
+			httputils.NotFound(w, "Product not found")
+			return
+		}
+		httputils.InternalServerError(w, "Failed to get product", err)
+		return
+	}
+
+	etag := productETag(p)
+	w.Header().Set("ETag", etag)
+
+	if notModified(r, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	httputils.Success(w, c.productToDTO(p))
+}
+
+// productETag derives a strong validator from the product's identity and
+// last write; every update or delete bumps UpdatedAt. Orders change stock and
+// scheduled price changes apply without an edit, so the sold-out flag and the
+// price are part of the validator too.
+func productETag(p *product.Product) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s|%s|%t",
+		p.ID, p.UpdatedAt.UTC().Format(time.RFC3339Nano), p.Price, p.Name, p.Stock.SoldOut())))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// notModified reports whether the request's If-None-Match matches etag.
+func notModified(r *http.Request, etag string) bool {
+	for _, candidate := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateProduct handles POST /api/v1/products
+func (c *ProductController) CreateProduct(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateProductRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.BadRequest(w, "Invalid request body", err)
+		return
+	}
+	availability, err := availabilityFromDTO(req.Availability)
+	if err != nil {
+		httputils.BadRequest(w, "Validation failed", err)
+		return
+	}
+
+	cmd := productusecase.CreateProductCommand{
+		ActorID:        middleware.GetUserID(r),
+		ActorRole:      middleware.GetUserRole(r),
+		RestaurantID:   req.RestaurantID,
+		CategoryID:     req.CategoryID,
+		Name:           req.Name,
+		Price:          req.Price,
+		ModifierGroups: modifierGroupsFromDTO(req.ModifierGroups),
+		Availability:   availability,
+	}
+	if req.Stock != nil {
+		cmd.StockMode = req.Stock.Mode
+		cmd.Stock = req.Stock.Quantity
+	}
+
+	createdProduct, err := c.productUseCase.CreateProduct(r.Context(), cmd)
+	if err != nil {
+		c.writeProductError(w, err, "Failed to create product")
+		return
+	}
+
+	httputils.Created(w, c.productToDTO(createdProduct))
+}
+
+// UpdateProduct handles PUT /api/v1/products/{id}
+func (c *ProductController) UpdateProduct(w http.ResponseWriter, r *http.Request) {
+	productID, ok := productIDFromPath(r)
+	if !ok {
+		httputils.BadRequest(w, "Invalid product ID", nil)
+		return
+	}
+
+	var req dto.UpdateProductRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.BadRequest(w, "Invalid request body", err)
+		return
+	}
+	availability, err := availabilityFromDTO(req.Availability)
+	if err != nil {
+		httputils.BadRequest(w, "Validation failed", err)
+		return
+	}
+
+	updatedProduct, err := c.productUseCase.UpdateProduct(r.Context(), productusecase.UpdateProductCommand{
+		ProductID:      productID,
+		ActorID:        middleware.GetUserID(r),
+		ActorRole:      middleware.GetUserRole(r),
+		CategoryID:     req.CategoryID,
+		Name:           req.Name,
+		Price:          req.Price,
+		ModifierGroups: modifierGroupsFromDTO(req.ModifierGroups),
+		Availability:   availability,
+	})
+	if err != nil {
+		c.writeProductError(w, err, "Failed to update product")
+		return
+	}
+
+	httputils.Success(w, c.productToDTO(updatedProduct))
+}
+
+// SetProductStock handles PUT /api/v1/products/{id}/stock
+func (c *ProductController) SetProductStock(w http.ResponseWriter, r *http.Request) {
+	productID, ok := productIDFromPath(r)
+	if !ok {
+		httputils.BadRequest(w, "Invalid product ID", nil)
+		return
+	}
+
+	var req dto.StockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.BadRequest(w, "Invalid request body", err)
+		return
+	}
+
+	restocked, err := c.productUseCase.SetProductStock(r.Context(), productusecase.SetProductStockCommand{
+		ProductID: productID,
+		ActorID:   middleware.GetUserID(r),
+		ActorRole: middleware.GetUserRole(r),
+		Mode:      req.Mode,
+		Quantity:  req.Quantity,
+	})
+	if err != nil {
+		c.writeProductError(w, err, "Failed to set product stock")
+		return
+	}
+
+	httputils.Success(w, dto.StockResponse{
+		ProductID:  restocked.ID,
+		Mode:       string(restocked.Stock.Mode),
+		Available:  restocked.Stock.Available,
+		DailyQuota: restocked.Stock.DailyQuota,
+		SoldOut:    restocked.Stock.SoldOut(),
+	})
+}
+
+// maxUploadBytes caps multipart request bodies before they are parsed; the
+// use case enforces the configured image size limit.
+const maxUploadBytes = 32 << 20
+
+// UploadProductImage handles PUT /api/v1/products/{id}/image
+// The image is sent as the "image" field of a multipart/form-data body.
+func (c *ProductController) UploadProductImage(w http.ResponseWriter, r *http.Request) {
+	productID, ok := productIDFromPath(r)
+	if !ok {
+		httputils.BadRequest(w, "Invalid product ID", nil)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadBytes)
+	file, _, err := r.FormFile("image")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			httputils.Error(w, http.StatusRequestEntityTooLarge, "Image too large", err)
+			return
+		}
+		httputils.BadRequest(w, "Image file is required", err)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		httputils.BadRequest(w, "Failed to read image", err)
+		return
+	}
+
+	updated, err := c.productUseCase.UploadProductImage(r.Context(), productusecase.UploadProductImageCommand{
+		ProductID: productID,
+		ActorID:   middleware.GetUserID(r),
+		ActorRole: middleware.GetUserRole(r),
+		Data:      data,
+	})
+	if err != nil {
+		c.writeProductError(w, err, "Failed to upload product image")
+		return
+	}
+
+	httputils.Success(w, c.productToDTO(updated))
+}
+
+// SchedulePriceChange handles POST /api/v1/products/{id}/prices
+func (c *ProductController) SchedulePriceChange(w http.ResponseWriter, r *http.Request) {
+	productID, ok := productIDFromPath(r)
+	if !ok {
+		httputils.BadRequest(w, "Invalid product ID", nil)
+		return
+	}
+
+	var req dto.SchedulePriceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.BadRequest(w, "Invalid request body", err)
+		return
+	}
+
+	change, err := c.productUseCase.SchedulePriceChange(r.Context(), productusecase.SchedulePriceChangeCommand{
+		ProductID:     productID,
+		ActorID:       middleware.GetUserID(r),
+		ActorRole:     middleware.GetUserRole(r),
+		Price:         req.Price,
+		EffectiveFrom: req.EffectiveFrom,
+	})
+	if err != nil {
+		c.writeProductError(w, err, "Failed to schedule price change")
+		return
+	}
+
+	httputils.Created(w, priceChangeToDTO(productusecase.PricePeriod{PriceChange: *change}, time.Now()))
+}
+
+// GetPriceHistory handles GET /api/v1/products/{id}/prices
+// ?at=<RFC 3339 time> narrows the history to the price in effect then.
+func (c *ProductController) GetPriceHistory(w http.ResponseWriter, r *http.Request) {
+	productID, ok := productIDFromPath(r)
+	if !ok {
+		httputils.BadRequest(w, "Invalid product ID", nil)
+		return
+	}
+
+	req := productusecase.PriceHistoryRequest{
+		ProductID: productID,
+		ActorID:   middleware.GetUserID(r),
+		ActorRole: middleware.GetUserRole(r),
+	}
+	if raw := r.URL.Query().Get("at"); raw != "" {
+		at, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			httputils.BadRequest(w, "Invalid at, expected an RFC 3339 time", err)
+			return
+		}
+		req.At = &at
+	}
+
+	periods, err := c.productUseCase.GetPriceHistory(r.Context(), req)
+	if err != nil {
+		c.writeProductError(w, err, "Failed to get price history")
+		return
+	}
+
+	now := time.Now()
+	resp := dto.PriceHistoryResponse{ProductID: productID, Prices: make([]dto.PriceChangeResponse, 0, len(periods))}
+	for _, period := range periods {
+		resp.Prices = append(resp.Prices, priceChangeToDTO(period, now))
+	}
+	httputils.Success(w, resp)
+}
+
+// priceChangeToDTO converts a price history entry, naming its status at now.
+func priceChangeToDTO(period productusecase.PricePeriod, now time.Time) dto.PriceChangeResponse {
+	resp := dto.PriceChangeResponse{
+		ID:            period.ID,
+		Price:         period.Price,
+		EffectiveFrom: period.EffectiveFrom.Format(time.RFC3339),
+		Status:        "current",
+		CreatedBy:     period.CreatedBy,
+		CreatedAt:     period.CreatedAt.Format(time.RFC3339),
+	}
+	if period.Until != nil {
+		resp.EffectiveUntil = period.Until.Format(time.RFC3339)
+	}
+	switch {
+	case period.IsScheduled(now):
+		resp.Status = "scheduled"
+	case period.Until != nil && !period.Until.After(now):
+		resp.Status = "past"
+	}
+	return resp
+}
+
+// DeleteProduct handles DELETE /api/v1/products/{id}
+// Products are soft deleted: they leave the menu but past orders keep them.
+func (c *ProductController) DeleteProduct(w http.ResponseWriter, r *http.Request) {
+	productID, ok := productIDFromPath(r)
+	if !ok {
+		httputils.BadRequest(w, "Invalid product ID", nil)
+		return
+	}
+
+	err := c.productUseCase.DeleteProduct(r.Context(), productusecase.DeleteProductCommand{
+		ProductID: productID,
+		ActorID:   middleware.GetUserID(r),
+		ActorRole: middleware.GetUserRole(r),
+	})
+	if err != nil {
+		c.writeProductError(w, err, "Failed to delete product")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeProductError maps product write failures to HTTP responses.
+func (c *ProductController) writeProductError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, product.ErrNotFound):
+		httputils.NotFound(w, "Product not found")
+	case errors.Is(err, restaurant.ErrNotOwner):
+		httputils.Forbidden(w, "Restaurant does not belong to user")
+	case strings.Contains(err.Error(), "validation failed"):
+		httputils.BadRequest(w, "Validation failed", err)
+	default:
+		httputils.InternalServerError(w, message, err)
+	}
+}
+
+// productIDFromPath extracts the ID from /api/v1/products/{id}
+func productIDFromPath(r *http.Request) (string, bool) {
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 || pathParts[3] == "" {
+		return "", false
+	}
+	return pathParts[3], true
+}
+
+// modifierGroupsFromDTO converts requested modifier groups to the domain.
+func modifierGroupsFromDTO(groups []dto.ModifierGroupRequest) []product.ModifierGroup {
+	if len(groups) == 0 {
+		return nil
+	}
+	result := make([]product.ModifierGroup, 0, len(groups))
+	for _, g := range groups {
+		group := product.ModifierGroup{
+			ID:            g.ID,
+			Name:          strings.TrimSpace(g.Name),
+			Required:      g.Required,
+			MinSelections: g.MinSelections,
+			MaxSelections: g.MaxSelections,
+		}
+		for _, o := range g.Options {
+			group.Options = append(group.Options, product.ModifierOption{
+				ID:         o.ID,
+				Name:       strings.TrimSpace(o.Name),
+				PriceDelta: o.PriceDelta,
+			})
+		}
+		result = append(result, group)
+	}
+	return result
+}
+
+// availabilityFromDTO parses requested availability windows. Range checks are
+// left to the domain; this only reads the weekday names and HH:MM times.
+func availabilityFromDTO(windows []dto.AvailabilityWindow) ([]timeutils.Window, error) {
+	return windowsFromDTO("availability", windows)
+}
+
+// windowsFromDTO parses the weekly windows of the request field named field.
+func windowsFromDTO(field string, windows []dto.AvailabilityWindow) ([]timeutils.Window, error) {
+	if len(windows) == 0 {
+		return nil, nil
+	}
+	result := make([]timeutils.Window, 0, len(windows))
+	for i, w := range windows {
+		var window timeutils.Window
+		var err error
+		for _, name := range w.Days {
+			day, err := timeutils.ParseWeekday(name)
+			if err != nil {
+				return nil, fmt.Errorf("%s[%d]: %w", field, i, err)
+			}
+			window.Days = append(window.Days, day)
+		}
+		if window.Start, err = timeutils.ParseClock(w.Start); err != nil {
+			return nil, fmt.Errorf("%s[%d]: start: %w", field, i, err)
+		}
+		if window.End, err = timeutils.ParseClock(w.End); err != nil {
+			return nil, fmt.Errorf("%s[%d]: end: %w", field, i, err)
+		}
+		result = append(result, window)
+	}
+	return result, nil
+}
+
+// availabilityToDTO converts availability windows for a response.
+func availabilityToDTO(windows []timeutils.Window) []dto.AvailabilityWindow {
+	if len(windows) == 0 {
+		return nil
+	}
+	result := make([]dto.AvailabilityWindow, 0, len(windows))
+	for _, w := range windows {
+		window := dto.AvailabilityWindow{Start: w.Start.String(), End: w.End.String()}
+		for _, day := range w.Days {
+			window.Days = append(window.Days, timeutils.FormatWeekday(day))
+		}
+		result = append(result, window)
+	}
+	return result
+}
+
 // productToDTO converts domain Product entity to DTO.
 func (c *ProductController) productToDTO(p *product.Product) dto.ProductResponse {
-	return dto.ProductResponse{
+	resp := dto.ProductResponse{
 		ID:           p.ID,
 		RestaurantID: p.RestaurantID,
+		CategoryID:   p.CategoryID,
 		Name:         p.Name,
 		Price:        p.Price,
+		ImageURL:     p.ImageURL,
+		ThumbnailURL: p.ThumbnailURL,
+		Availability: availabilityToDTO(p.Availability),
+		SoldOut:      p.Stock.SoldOut(),
 		CreatedAt:    p.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    p.UpdatedAt.Format(time.RFC3339),
+	}
+	for _, g := range p.ModifierGroups {
+		group := dto.ModifierGroupResponse{
+			ID:            g.ID,
+			Name:          g.Name,
+			Required:      g.Required,
+			MinSelections: g.MinSelections,
+			MaxSelections: g.MaxSelections,
+			Options:       make([]dto.ModifierOptionResponse, 0, len(g.Options)),
+		}
+		for _, o := range g.Options {
+			group.Options = append(group.Options, dto.ModifierOptionResponse{ID: o.ID, Name: o.Name, PriceDelta: o.PriceDelta})
+		}
+		resp.ModifierGroups = append(resp.ModifierGroups, group)
 	}
+	return resp
 }
@@ -0,0 +1,220 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	restaurantusecase "foodie/backend/internal/application/usecase/restaurant"
+	"foodie/backend/internal/domain/restaurant"
+	"foodie/backend/internal/interfaces/http/dto"
+	"foodie/backend/internal/interfaces/http/middleware"
+	httputils "foodie/backend/pkg/utils/http"
+	"foodie/backend/pkg/utils/pagination"
+)
+
+// RestaurantController handles HTTP requests for restaurants.
+type RestaurantController struct {
+	restaurantUseCase restaurantusecase.UseCase
+}
+
+// NewRestaurantController creates a new restaurant controller.
+func NewRestaurantController(restaurantUseCase restaurantusecase.UseCase) *RestaurantController {
+	return &RestaurantController{
+		restaurantUseCase: restaurantUseCase,
+	}
+}
+
+// ListRestaurants handles GET /api/v1/restaurants
+func (c *RestaurantController) ListRestaurants(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	page := pagination.ParsePage(query.Get("page"))
+	offset := pagination.ParseOffset(query.Get("offset"))
+	limit := pagination.ParseLimit(query.Get("limit"), 20, 1, 100)
+
+	// An explicit offset wins, and the reported page is derived from it
+	actualOffset := offset
+	if actualOffset == 0 {
+		actualOffset = pagination.CalculateOffset(page, limit)
+	} else {
+		page = pagination.CalculatePageFromOffset(offset, limit)
+	}
+
+	req := restaurantusecase.ListRestaurantsRequest{
+		Name:    query.Get("name"),
+		OwnerID: query.Get("owner_id"),
+		Page:    page,
+		Offset:  actualOffset,
+		Limit:   limit,
+	}
+	if statuses := query.Get("status"); statuses != "" {
+		req.Statuses = strings.Split(statuses, ",")
+	}
+
+	restaurants, total, err := c.restaurantUseCase.ListRestaurants(r.Context(), req)
+	if err != nil {
+		c.writeRestaurantError(w, err, "Failed to list restaurants")
+		return
+	}
+
+	paginationMeta := pagination.CalculateMeta(page, limit, total)
+	now := time.Now()
+	data := make([]dto.RestaurantResponse, 0, len(restaurants))
+	for i := range restaurants {
+		data = append(data, restaurantToDTO(&restaurants[i], now))
+	}
+
+	httputils.Success(w, dto.ListRestaurantsResponse{
+		Data: data,
+		Pagination: dto.PaginationMeta{
+			CurrentPage: paginationMeta.CurrentPage,
+			PerPage:     paginationMeta.PerPage,
+			Offset:      actualOffset,
+			Total:       paginationMeta.Total,
+			TotalPages:  paginationMeta.TotalPages,
+			HasNext:     actualOffset+len(restaurants) < total,
+			HasPrev:     actualOffset > 0,
+		},
+	})
+}
+
+// GetRestaurant handles GET /api/v1/restaurants/{id}
+func (c *RestaurantController) GetRestaurant(w http.ResponseWriter, r *http.Request) {
+	restaurantID, ok := pathSegment(r, 3)
+	if !ok {
+		httputils.BadRequest(w, "Invalid restaurant ID", nil)
+		return
+	}
+
+	found, err := c.restaurantUseCase.GetRestaurant(r.Context(), restaurantID)
+	if err != nil {
+		c.writeRestaurantError(w, err, "Failed to get restaurant")
+		return
+	}
+
+	httputils.Success(w, restaurantToDTO(found, time.Now()))
+}
+
+// CreateRestaurant handles POST /api/v1/restaurants
+func (c *RestaurantController) CreateRestaurant(w http.ResponseWriter, r *http.Request) {
+	var req dto.RestaurantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.BadRequest(w, "Invalid request body", err)
+		return
+	}
+	openingHours, err := windowsFromDTO("opening_hours", req.OpeningHours)
+	if err != nil {
+		httputils.BadRequest(w, "Validation failed", err)
+		return
+	}
+
+	created, err := c.restaurantUseCase.CreateRestaurant(r.Context(), restaurantusecase.CreateRestaurantCommand{
+		ActorID:      middleware.GetUserID(r),
+		ActorRole:    middleware.GetUserRole(r),
+		OwnerID:      req.OwnerID,
+		Name:         req.Name,
+		Address:      req.Address,
+		Location:     restaurant.Location{Latitude: req.Location.Latitude, Longitude: req.Location.Longitude},
+		TimeZone:     req.TimeZone,
+		Status:       req.Status,
+		OpeningHours: openingHours,
+	})
+	if err != nil {
+		c.writeRestaurantError(w, err, "Failed to create restaurant")
+		return
+	}
+
+	httputils.Created(w, restaurantToDTO(created, time.Now()))
+}
+
+// UpdateRestaurant handles PUT /api/v1/restaurants/{id}
+func (c *RestaurantController) UpdateRestaurant(w http.ResponseWriter, r *http.Request) {
+	restaurantID, ok := pathSegment(r, 3)
+	if !ok {
+		httputils.BadRequest(w, "Invalid restaurant ID", nil)
+		return
+	}
+
+	var req dto.RestaurantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.BadRequest(w, "Invalid request body", err)
+		return
+	}
+	openingHours, err := windowsFromDTO("opening_hours", req.OpeningHours)
+	if err != nil {
+		httputils.BadRequest(w, "Validation failed", err)
+		return
+	}
+
+	updated, err := c.restaurantUseCase.UpdateRestaurant(r.Context(), restaurantusecase.UpdateRestaurantCommand{
+		RestaurantID: restaurantID,
+		ActorID:      middleware.GetUserID(r),
+		ActorRole:    middleware.GetUserRole(r),
+		Name:         req.Name,
+		Address:      req.Address,
+		Location:     restaurant.Location{Latitude: req.Location.Latitude, Longitude: req.Location.Longitude},
+		TimeZone:     req.TimeZone,
+		Status:       req.Status,
+		OpeningHours: openingHours,
+	})
+	if err != nil {
+		c.writeRestaurantError(w, err, "Failed to update restaurant")
+		return
+	}
+
+	httputils.Success(w, restaurantToDTO(updated, time.Now()))
+}
+
+// DeleteRestaurant handles DELETE /api/v1/restaurants/{id}
+// Restaurants are soft deleted: they stop taking orders but past orders keep them.
+func (c *RestaurantController) DeleteRestaurant(w http.ResponseWriter, r *http.Request) {
+	restaurantID, ok := pathSegment(r, 3)
+	if !ok {
+		httputils.BadRequest(w, "Invalid restaurant ID", nil)
+		return
+	}
+
+	err := c.restaurantUseCase.DeleteRestaurant(r.Context(), restaurantusecase.DeleteRestaurantCommand{
+		RestaurantID: restaurantID,
+		ActorID:      middleware.GetUserID(r),
+		ActorRole:    middleware.GetUserRole(r),
+	})
+	if err != nil {
+		c.writeRestaurantError(w, err, "Failed to delete restaurant")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeRestaurantError maps restaurant failures to HTTP responses.
+func (c *RestaurantController) writeRestaurantError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, restaurant.ErrNotFound):
+		httputils.NotFound(w, "Restaurant not found")
+	case errors.Is(err, restaurant.ErrNotOwner):
+		httputils.Forbidden(w, "Restaurant does not belong to user")
+	case strings.Contains(err.Error(), "validation failed"):
+		httputils.BadRequest(w, "Validation failed", err)
+	default:
+		httputils.InternalServerError(w, message, err)
+	}
+}
+
+// restaurantToDTO converts a domain Restaurant entity to DTO; now decides open_now.
+func restaurantToDTO(rest *restaurant.Restaurant, now time.Time) dto.RestaurantResponse {
+	return dto.RestaurantResponse{
+		ID:           rest.ID,
+		Name:         rest.Name,
+		Address:      rest.Address,
+		Location:     dto.Location{Latitude: rest.Location.Latitude, Longitude: rest.Location.Longitude},
+		TimeZone:     rest.TimeZone,
+		Status:       string(rest.Status),
+		OpeningHours: availabilityToDTO(rest.OpeningHours),
+		OpenNow:      rest.IsOpenAt(now),
+		CreatedAt:    rest.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    rest.UpdatedAt.Format(time.RFC3339),
+	}
+}
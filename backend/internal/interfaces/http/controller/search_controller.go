@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	searchusecase "foodie/backend/internal/application/usecase/search"
+	"foodie/backend/internal/interfaces/http/dto"
+	httputils "foodie/backend/pkg/utils/http"
+	"foodie/backend/pkg/utils/pagination"
+)
+
+// SearchController handles HTTP requests for catalogue search.
+type SearchController struct {
+	searchUseCase searchusecase.UseCase
+	products      *ProductController // Renders products the same way the product endpoints do
+}
+
+// NewSearchController creates a new search controller.
+func NewSearchController(searchUseCase searchusecase.UseCase) *SearchController {
+	return &SearchController{
+		searchUseCase: searchUseCase,
+		products:      NewProductController(nil),
+	}
+}
+
+// Search handles GET /api/v1/search?q=
+func (c *SearchController) Search(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	page := pagination.ParsePage(query.Get("page"))
+	offset := pagination.ParseOffset(query.Get("offset"))
+	limit := pagination.ParseLimit(query.Get("limit"), 20, 1, 100)
+
+	// An explicit offset wins, and the reported page is derived from it
+	actualOffset := offset
+	if actualOffset == 0 {
+		actualOffset = pagination.CalculateOffset(page, limit)
+	} else {
+		page = pagination.CalculatePageFromOffset(offset, limit)
+	}
+
+	results, err := c.searchUseCase.Search(r.Context(), searchusecase.SearchRequest{
+		Query:        query.Get("q"),
+		RestaurantID: query.Get("restaurant_id"),
+		MinPrice:     query.Get("min_price"),
+		MaxPrice:     query.Get("max_price"),
+		Page:         page,
+		Offset:       actualOffset,
+		Limit:        limit,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "validation failed") {
+			httputils.BadRequest(w, "Validation failed", err)
+			return
+		}
+		httputils.InternalServerError(w, "Failed to search", err)
+		return
+	}
+
+	total := results.ProductTotal
+	paginationMeta := pagination.CalculateMeta(page, limit, total)
+	data := make([]dto.SearchResultResponse, 0, len(results.Products))
+	for i := range results.Products {
+		data = append(data, dto.SearchResultResponse{
+			ProductResponse: c.products.productToDTO(&results.Products[i].Product),
+			Rank:            results.Products[i].Rank,
+		})
+	}
+	now := time.Now()
+	restaurants := make([]dto.RestaurantSearchResultResponse, 0, len(results.Restaurants))
+	for i := range results.Restaurants {
+		restaurants = append(restaurants, dto.RestaurantSearchResultResponse{
+			RestaurantResponse: restaurantToDTO(&results.Restaurants[i].Restaurant, now),
+			Rank:               results.Restaurants[i].Rank,
+		})
+	}
+
+	httputils.Success(w, dto.SearchResponse{
+		Query: query.Get("q"),
+		Data:  data,
+		Pagination: dto.PaginationMeta{
+			CurrentPage: paginationMeta.CurrentPage,
+			PerPage:     paginationMeta.PerPage,
+			Offset:      actualOffset,
+			Total:       paginationMeta.Total,
+			TotalPages:  paginationMeta.TotalPages,
+			HasNext:     actualOffset+len(results.Products) < total,
+			HasPrev:     actualOffset > 0,
+		},
+		Restaurants:     restaurants,
+		RestaurantTotal: results.RestaurantTotal,
+	})
+}
@@ -0,0 +1,34 @@
+package dto
+
+// RegisterRequest represents the request to create an account.
+type RegisterRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,min=8"`
+	Name     string `json:"name" validate:"required"`
+	Role     string `json:"role,omitempty"` // user (default) or restaurant
+}
+
+// LoginRequest represents the request to sign in.
+type LoginRequest struct {
+	Email    string `json:"email" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+// AuthResponse is a signed-in user with the access token to send as
+// "Authorization: Bearer <access_token>".
+type AuthResponse struct {
+	AccessToken string       `json:"access_token"`
+	TokenType   string       `json:"token_type"` // Always "Bearer"
+	ExpiresIn   int64        `json:"expires_in"` // Seconds until the token expires
+	ExpiresAt   string       `json:"expires_at"`
+	User        UserResponse `json:"user"`
+}
+
+// UserResponse represents a user account in the API response.
+type UserResponse struct {
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	Name      string `json:"name"`
+	Role      string `json:"role"`
+	CreatedAt string `json:"created_at"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+// MenuResponse represents a restaurant's menu: visible categories in order,
+// then any products that are not in a category.
+type MenuResponse struct {
+	RestaurantID  string                 `json:"restaurant_id"`
+	Categories    []MenuCategoryResponse `json:"categories"`
+	Uncategorized []ProductResponse      `json:"uncategorized"`
+}
+
+// MenuCategoryResponse represents one category of a menu with its products.
+type MenuCategoryResponse struct {
+	CategoryResponse
+	Products []ProductResponse `json:"products"`
+}
+
+// CategoryResponse represents a menu category in the API response.
+type CategoryResponse struct {
+	ID           string               `json:"id"`
+	RestaurantID string               `json:"restaurant_id"`
+	Name         string               `json:"name"`
+	SortOrder    int                  `json:"sort_order"`
+	Visible      bool                 `json:"visible"`
+	Availability []AvailabilityWindow `json:"availability,omitempty"` // Omitted when always available
+	CreatedAt    string               `json:"created_at"`
+	UpdatedAt    string               `json:"updated_at"`
+}
+
+// CategoryRequest represents the request to create or replace a menu category.
+type CategoryRequest struct {
+	Name      string `json:"name" validate:"required"`
+	SortOrder int    `json:"sort_order,omitempty"` // Lower values are listed first
+	Visible   *bool  `json:"visible,omitempty"`    // Default: true
+	// Availability limits when the category's products can be ordered; omit for always.
+	Availability []AvailabilityWindow `json:"availability,omitempty"`
+}
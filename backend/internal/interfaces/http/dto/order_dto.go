@@ -1,36 +1,154 @@
 package dto
 
+import "foodie/backend/pkg/money"
+
 // CreateOrderRequest represents the request to create an order.
+// The order is placed for the authenticated user.
 type CreateOrderRequest struct {
-	UserID          string             `json:"user_id" validate:"required"`
 	RestaurantID    string             `json:"restaurant_id" validate:"required"`
 	Items           []OrderItemRequest `json:"items" validate:"required,min=1"`
 	PaymentMethod   string             `json:"payment_method" validate:"required"`
 	DeliveryAddress string             `json:"delivery_address" validate:"required"`
+	PromoCode       string             `json:"promo_code,omitempty"`
+	QuoteID         string             `json:"quote_id,omitempty"` // From POST /orders/quote; locks the quoted price
+}
+
+// QuoteRequest represents the request to price a basket without placing an order.
+type QuoteRequest struct {
+	RestaurantID    string             `json:"restaurant_id" validate:"required"`
+	Items           []OrderItemRequest `json:"items" validate:"required,min=1"`
+	DeliveryAddress string             `json:"delivery_address" validate:"required"`
+	PromoCode       string             `json:"promo_code,omitempty"`
+}
+
+// QuoteResponse represents a priced basket.
+// Lines itemise the total; pass QuoteID to POST /orders before ExpiresAt to keep the price.
+type QuoteResponse struct {
+	QuoteID             string              `json:"quote_id"`
+	ExpiresAt           string              `json:"expires_at"`
+	RestaurantID        string              `json:"restaurant_id"`
+	Items               []OrderItemResponse `json:"items"`
+	Lines               []QuoteLineResponse `json:"lines"`
+	Subtotal            money.Money         `json:"subtotal"`
+	Discount            money.Money         `json:"discount"`
+	DeliveryFee         money.Money         `json:"delivery_fee"`
+	ServiceFee          money.Money         `json:"service_fee"`
+	Tax                 money.Money         `json:"tax"`
+	Total               money.Money         `json:"total"`
+	PromoCode           string              `json:"promo_code,omitempty"`
+	DistanceKm          float64             `json:"distance_km"`
+	EstimatedDeliveryAt string              `json:"estimated_delivery_at"`
+}
+
+// QuoteLineResponse is one line of a quote breakdown. Discounts are negative.
+type QuoteLineResponse struct {
+	Type   string      `json:"type"` // item, discount, delivery_fee, service_fee, tax
+	Label  string      `json:"label"`
+	Amount money.Money `json:"amount"`
 }
 
 // OrderItemRequest represents an item in the order request.
 type OrderItemRequest struct {
-	ProductID string `json:"product_id" validate:"required"`
-	Quantity  int    `json:"quantity" validate:"required,min=1"`
+	ProductID string                `json:"product_id" validate:"required"`
+	Quantity  int                   `json:"quantity" validate:"required,min=1"`
+	Modifiers []ItemModifierRequest `json:"modifiers,omitempty"`
+}
+
+// ItemModifierRequest selects one option of a product's modifier group.
+type ItemModifierRequest struct {
+	GroupID  string `json:"group_id" validate:"required"`
+	OptionID string `json:"option_id" validate:"required"`
+}
+
+// UpdateOrderStatusRequest represents the request to change an order's status.
+type UpdateOrderStatusRequest struct {
+	Status string `json:"status" validate:"required"`
+	Note   string `json:"note,omitempty"`
+}
+
+// CancelOrderRequest represents the request to cancel an order.
+type CancelOrderRequest struct {
+	Reason string `json:"reason" validate:"required"`
+	Note   string `json:"note,omitempty"`
+}
+
+// AcceptOrderRequest represents a restaurant accepting an order.
+type AcceptOrderRequest struct {
+	PrepTimeMinutes int `json:"prep_time_minutes,omitempty"` // Default: the configured preparation time
+}
+
+// RejectOrderRequest represents a restaurant declining an order.
+type RejectOrderRequest struct {
+	Reason string `json:"reason,omitempty"` // Default: restaurant_too_busy
+	Note   string `json:"note,omitempty"`
 }
 
 // OrderResponse represents an order in the API response.
 type OrderResponse struct {
-	ID        string              `json:"id"`
-	UserID    string              `json:"user_id"`
-	Status    string              `json:"status"`
-	Total     float64             `json:"total,omitempty"`
-	CreatedAt string              `json:"created_at"`
-	Items     []OrderItemResponse `json:"items,omitempty"`
+	ID                  string                 `json:"id"`
+	UserID              string                 `json:"user_id"`
+	RestaurantID        string                 `json:"restaurant_id"`
+	Status              string                 `json:"status"`
+	Subtotal            money.Money            `json:"subtotal"`
+	Discount            money.Money            `json:"discount"`
+	ServiceFee          money.Money            `json:"service_fee"`
+	Tax                 money.Money            `json:"tax"`
+	Total               money.Money            `json:"total"`
+	PromoCode           string                 `json:"promo_code,omitempty"`
+	PaymentStatus       string                 `json:"payment_status,omitempty"`
+	DeliveryFee         money.Money            `json:"delivery_fee"`
+	DistanceKm          float64                `json:"distance_km,omitempty"`
+	PrepTimeMinutes     int                    `json:"prep_time_minutes,omitempty"`
+	EstimatedDeliveryAt string                 `json:"estimated_delivery_at,omitempty"`
+	Cancellation        *CancellationResponse  `json:"cancellation,omitempty"`
+	CreatedAt           string                 `json:"created_at"`
+	UpdatedAt           string                 `json:"updated_at"`
+	Items               []OrderItemResponse    `json:"items,omitempty"`
+	Timeline            []StatusChangeResponse `json:"timeline,omitempty"` // Only with ?include=timeline
+}
+
+// CancellationResponse describes who cancelled an order and why.
+type CancellationResponse struct {
+	CancelledBy string `json:"cancelled_by"`
+	Role        string `json:"role"`
+	Reason      string `json:"reason"`
+	Note        string `json:"note,omitempty"`
+	CancelledAt string `json:"cancelled_at"`
+}
+
+// StatusChangeResponse represents one entry of an order's status timeline.
+type StatusChangeResponse struct {
+	FromStatus string `json:"from_status,omitempty"`
+	ToStatus   string `json:"to_status"`
+	ActorID    string `json:"actor_id,omitempty"`
+	ActorRole  string `json:"actor_role,omitempty"`
+	Note       string `json:"note,omitempty"`
+	ChangedAt  string `json:"changed_at"`
+}
+
+// OrderTimelineResponse represents the full status history of an order.
+type OrderTimelineResponse struct {
+	OrderID  string                 `json:"order_id"`
+	Timeline []StatusChangeResponse `json:"timeline"`
 }
 
 // OrderItemResponse represents an item in the order response.
 type OrderItemResponse struct {
-	ProductID   string  `json:"product_id"`
-	ProductName string  `json:"product_name"`
-	Quantity    int     `json:"quantity"`
-	Price       float64 `json:"price"`
+	ProductID   string                 `json:"product_id"`
+	ProductName string                 `json:"product_name"`
+	Quantity    int                    `json:"quantity"`
+	Price       money.Money            `json:"price"`      // Base price of one unit
+	UnitPrice   money.Money            `json:"unit_price"` // Price of one unit including modifiers
+	Modifiers   []ItemModifierResponse `json:"modifiers,omitempty"`
+}
+
+// ItemModifierResponse is a modifier option chosen for an order item.
+type ItemModifierResponse struct {
+	GroupID    string      `json:"group_id"`
+	GroupName  string      `json:"group_name"`
+	OptionID   string      `json:"option_id"`
+	OptionName string      `json:"option_name"`
+	PriceDelta money.Money `json:"price_delta"`
 }
 
 // ListOrdersRequest represents query parameters for listing orders.
@@ -1,17 +1,137 @@
 package dto
 
+import (
+	"time"
+
+	"foodie/backend/pkg/money"
+)
+
 // ProductResponse represents a product in the API response.
 type ProductResponse struct {
-	ID           string  `json:"id"`
-	RestaurantID string  `json:"restaurant_id"`
-	Name         string  `json:"name"`
-	Price        float64 `json:"price"`
-	CreatedAt    string  `json:"created_at"`
+	ID             string                  `json:"id"`
+	RestaurantID   string                  `json:"restaurant_id"`
+	CategoryID     string                  `json:"category_id,omitempty"`
+	Name           string                  `json:"name"`
+	Price          money.Money             `json:"price"`
+	ImageURL       string                  `json:"image_url,omitempty"`
+	ThumbnailURL   string                  `json:"thumbnail_url,omitempty"`
+	ModifierGroups []ModifierGroupResponse `json:"modifier_groups,omitempty"`
+	Availability   []AvailabilityWindow    `json:"availability,omitempty"` // Omitted when always available
+	SoldOut        bool                    `json:"sold_out"`
+	CreatedAt      string                  `json:"created_at"`
+	UpdatedAt      string                  `json:"updated_at"`
+}
+
+// ModifierGroupResponse represents a choice offered with a product, e.g. size or toppings.
+type ModifierGroupResponse struct {
+	ID            string                   `json:"id"`
+	Name          string                   `json:"name"`
+	Required      bool                     `json:"required"`
+	MinSelections int                      `json:"min_selections"`
+	MaxSelections int                      `json:"max_selections,omitempty"` // Omitted when any number may be chosen
+	Options       []ModifierOptionResponse `json:"options"`
+}
+
+// ModifierOptionResponse represents one option of a modifier group.
+type ModifierOptionResponse struct {
+	ID         string      `json:"id"`
+	Name       string      `json:"name"`
+	PriceDelta money.Money `json:"price_delta"`
+}
+
+// ModifierGroupRequest defines a modifier group. Omit IDs for new groups and
+// options; keep them when editing so existing carts and quotes stay valid.
+type ModifierGroupRequest struct {
+	ID            string                  `json:"id,omitempty"`
+	Name          string                  `json:"name" validate:"required"`
+	Required      bool                    `json:"required,omitempty"`
+	MinSelections int                     `json:"min_selections,omitempty"` // Default: 1 for required groups
+	MaxSelections int                     `json:"max_selections,omitempty"` // Default: no limit
+	Options       []ModifierOptionRequest `json:"options" validate:"required,min=1"`
+}
+
+// ModifierOptionRequest defines one option of a modifier group.
+type ModifierOptionRequest struct {
+	ID         string      `json:"id,omitempty"`
+	Name       string      `json:"name" validate:"required"`
+	PriceDelta money.Money `json:"price_delta"` // May be negative; default 0
+}
+
+// AvailabilityWindow is a weekly period in the restaurant's time zone during
+// which a product or category can be ordered. A window whose end is not after
+// its start runs past midnight.
+type AvailabilityWindow struct {
+	Days  []string `json:"days,omitempty"` // mon..sun or full names; default: every day
+	Start string   `json:"start"`          // HH:MM
+	End   string   `json:"end"`            // HH:MM; 24:00 for midnight
+}
+
+// CreateProductRequest represents the request to add a product to a menu.
+type CreateProductRequest struct {
+	RestaurantID   string                 `json:"restaurant_id" validate:"required"`
+	CategoryID     string                 `json:"category_id,omitempty"`
+	Name           string                 `json:"name" validate:"required"`
+	Price          money.Money            `json:"price" validate:"required"`
+	Stock          *StockRequest          `json:"stock,omitempty"` // Default: unlimited
+	ModifierGroups []ModifierGroupRequest `json:"modifier_groups,omitempty"`
+	Availability   []AvailabilityWindow   `json:"availability,omitempty"` // Default: always available
+}
+
+// StockRequest sets how a product's stock is tracked.
+type StockRequest struct {
+	Mode     string `json:"mode" validate:"required"` // unlimited, daily or count
+	Quantity int    `json:"quantity,omitempty"`       // Daily quota or units on hand, depending on mode
+}
+
+// StockResponse represents a product's stock, as seen by its restaurant.
+type StockResponse struct {
+	ProductID  string `json:"product_id"`
+	Mode       string `json:"mode"`
+	Available  int    `json:"available,omitempty"`
+	DailyQuota int    `json:"daily_quota,omitempty"`
+	SoldOut    bool   `json:"sold_out"`
+}
+
+// UpdateProductRequest represents the request to replace a product's details.
+type UpdateProductRequest struct {
+	CategoryID     string                 `json:"category_id,omitempty"` // Omit to uncategorize
+	Name           string                 `json:"name" validate:"required"`
+	Price          money.Money            `json:"price" validate:"required"`
+	ModifierGroups []ModifierGroupRequest `json:"modifier_groups,omitempty"` // Replaces all groups
+	Availability   []AvailabilityWindow   `json:"availability,omitempty"`    // Replaces all windows; omit for always
+}
+
+// SchedulePriceRequest represents the request to change a product's price at a future time.
+type SchedulePriceRequest struct {
+	Price         money.Money `json:"price" validate:"required"`
+	EffectiveFrom time.Time   `json:"effective_from" validate:"required"` // RFC 3339, in the future
+}
+
+// PriceChangeResponse represents one entry of a product's price history.
+type PriceChangeResponse struct {
+	ID             string      `json:"id"`
+	Price          money.Money `json:"price"`
+	EffectiveFrom  string      `json:"effective_from"`
+	EffectiveUntil string      `json:"effective_until,omitempty"` // Omitted for the latest price
+	Status         string      `json:"status"`                    // scheduled, current or past
+	CreatedBy      string      `json:"created_by,omitempty"`
+	CreatedAt      string      `json:"created_at"`
+}
+
+// PriceHistoryResponse represents a product's prices, oldest first.
+type PriceHistoryResponse struct {
+	ProductID string                `json:"product_id"`
+	Prices    []PriceChangeResponse `json:"prices"`
 }
 
 // ListProductsRequest represents query parameters for listing products.
 type ListProductsRequest struct {
 	RestaurantID string `json:"restaurant_id,omitempty"`
+	CategoryID   string `json:"category_id,omitempty"`
+	Name         string `json:"name,omitempty"`
+	MinPrice     string `json:"min_price,omitempty"`
+	MaxPrice     string `json:"max_price,omitempty"`
+	Sort         string `json:"sort,omitempty"`   // name, price or newest; prefix with "-" for descending
 	Page         int    `json:"page,omitempty"`   // Page number (default: 1)
 	Offset       int    `json:"offset,omitempty"` // Offset (default: 0, calculated from page if page provided)
 	Limit        int    `json:"limit,omitempty"`  // Items per page (default: 20)
@@ -0,0 +1,41 @@
+package dto
+
+// RestaurantRequest represents the request to create or replace a restaurant.
+type RestaurantRequest struct {
+	Name     string   `json:"name" validate:"required"`
+	Address  string   `json:"address" validate:"required"`
+	Location Location `json:"location"`
+	TimeZone string   `json:"time_zone,omitempty"` // IANA zone; default: the platform time zone on create, unchanged on update
+	Status   string   `json:"status,omitempty"`    // open (default), paused or closed; unchanged on update when omitted
+	// OpeningHours are the weekly windows orders are accepted in; omit for always.
+	OpeningHours []AvailabilityWindow `json:"opening_hours,omitempty"`
+	// OwnerID is the account that will manage the restaurant. Only admins set
+	// it, and only on create; restaurant accounts own what they create.
+	OwnerID string `json:"owner_id,omitempty"`
+}
+
+// Location is a point on the map.
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// RestaurantResponse represents a restaurant in the API response.
+type RestaurantResponse struct {
+	ID           string               `json:"id"`
+	Name         string               `json:"name"`
+	Address      string               `json:"address"`
+	Location     Location             `json:"location"`
+	TimeZone     string               `json:"time_zone"`
+	Status       string               `json:"status"`
+	OpeningHours []AvailabilityWindow `json:"opening_hours,omitempty"` // Omitted when always open
+	OpenNow      bool                 `json:"open_now"`                // Whether orders are accepted right now
+	CreatedAt    string               `json:"created_at"`
+	UpdatedAt    string               `json:"updated_at"`
+}
+
+// ListRestaurantsResponse represents the response for listing restaurants with pagination.
+type ListRestaurantsResponse struct {
+	Data       []RestaurantResponse `json:"data"`
+	Pagination PaginationMeta       `json:"pagination"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+// SearchResultResponse represents a product matching a search.
+type SearchResultResponse struct {
+	ProductResponse
+	Rank float64 `json:"rank"` // Relevance; higher is better
+}
+
+// RestaurantSearchResultResponse represents a restaurant matching a search.
+type RestaurantSearchResultResponse struct {
+	RestaurantResponse
+	Rank float64 `json:"rank"` // Relevance; higher is better
+}
+
+// SearchResponse represents one page of search results.
+type SearchResponse struct {
+	Query      string                 `json:"query"`
+	Data       []SearchResultResponse `json:"data"` // Products
+	Pagination PaginationMeta         `json:"pagination"`
+	// Restaurants are the best matching restaurants, listed on the first page only.
+	Restaurants     []RestaurantSearchResultResponse `json:"restaurants"`
+	RestaurantTotal int                              `json:"restaurant_total"`
+}
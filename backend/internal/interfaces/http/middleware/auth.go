@@ -2,8 +2,12 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
+
+	"foodie/backend/internal/domain/user"
+	"foodie/backend/pkg/jwt"
 )
 
 // ContextKey is a type for context keys to avoid collisions.
@@ -16,93 +20,80 @@ const (
 	UserRoleKey ContextKey = "user_role"
 )
 
-// AuthMiddleware validates JWT tokens and extracts user information.
-// This is a placeholder implementation - replace with actual JWT validation.
-func AuthMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Extract token from Authorization header
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" {
-			http.Error(w, "Authorization header required", http.StatusUnauthorized)
-			return
-		}
-
-		// Expected format: "Bearer <token>"
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			http.Error(w, "Invalid authorization header format", http.StatusUnauthorized)
-			return
-		}
-
-		token := parts[1]
-		if token == "" {
-			http.Error(w, "Token is required", http.StatusUnauthorized)
-			return
-		}
-
-		// TODO: Validate JWT token
-		// For now, this is a placeholder that accepts any non-empty token
-		// In production, you should:
-		// 1. Parse and validate the JWT
-		// 2. Extract user ID and role from claims
-		// 3. Verify token signature and expiration
-		// 4. Check if user exists and is active
-
-		// Placeholder: Extract user info from token
-		// In real implementation, decode JWT and extract claims
-		userID := extractUserIDFromToken(token)     // Placeholder
-		userRole := extractUserRoleFromToken(token) // Placeholder
-
-		if userID == "" {
-			http.Error(w, "Invalid token", http.StatusUnauthorized)
-			return
-		}
-
-		// Add user info to request context
-		ctx := context.WithValue(r.Context(), UserIDKey, userID)
-		ctx = context.WithValue(ctx, UserRoleKey, userRole)
-		r = r.WithContext(ctx)
-
-		next.ServeHTTP(w, r)
-	})
+// TokenVerifier checks access tokens, e.g. *jwt.Verifier.
+type TokenVerifier interface {
+	Verify(token string) (*jwt.Claims, error)
 }
 
-// extractUserIDFromToken is a placeholder - replace with actual JWT parsing.
-func extractUserIDFromToken(token string) string {
-	// TODO: Parse JWT and extract user_id claim
-	// For now, return empty string to force authentication
-	return ""
-}
+// AuthMiddleware requires a valid "Authorization: Bearer <token>" header and
+// puts the token's subject and role in the request context. Tokens without a
+// subject or with an unknown role are rejected.
+func AuthMiddleware(verifier TokenVerifier) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authHeader := r.Header.Get("Authorization")
+			if authHeader == "" {
+				http.Error(w, "Authorization header required", http.StatusUnauthorized)
+				return
+			}
+
+			token, ok := bearerToken(authHeader)
+			if !ok {
+				http.Error(w, "Invalid authorization header format", http.StatusUnauthorized)
+				return
+			}
+
+			claims, err := verifier.Verify(token)
+			if errors.Is(err, jwt.ErrExpired) {
+				http.Error(w, "Token has expired", http.StatusUnauthorized)
+				return
+			}
+			if err != nil || !hasIdentity(claims) {
+				http.Error(w, "Invalid token", http.StatusUnauthorized)
+				return
+			}
 
-// extractUserRoleFromToken is a placeholder - replace with actual JWT parsing.
-func extractUserRoleFromToken(token string) string {
-	// TODO: Parse JWT and extract role claim
-	return "user"
+			next.ServeHTTP(w, r.WithContext(withClaims(r.Context(), claims)))
+		})
+	}
 }
 
 // OptionalAuthMiddleware allows requests with or without authentication.
-// If a valid token is present, user info is added to context.
-func OptionalAuthMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		authHeader := r.Header.Get("Authorization")
-		if authHeader != "" {
-			parts := strings.Split(authHeader, " ")
-			if len(parts) == 2 && parts[0] == "Bearer" {
-				token := parts[1]
-				if token != "" {
-					// Try to extract user info, but don't fail if invalid
-					userID := extractUserIDFromToken(token)
-					if userID != "" {
-						userRole := extractUserRoleFromToken(token)
-						ctx := context.WithValue(r.Context(), UserIDKey, userID)
-						ctx = context.WithValue(ctx, UserRoleKey, userRole)
-						r = r.WithContext(ctx)
-					}
+// If a valid token is present, user info is added to context; an invalid
+// token is treated as no token.
+func OptionalAuthMiddleware(verifier TokenVerifier) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token, ok := bearerToken(r.Header.Get("Authorization")); ok {
+				if claims, err := verifier.Verify(token); err == nil && hasIdentity(claims) {
+					r = r.WithContext(withClaims(r.Context(), claims))
 				}
 			}
-		}
-		next.ServeHTTP(w, r)
-	})
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer <token>" header value.
+func bearerToken(authHeader string) (string, bool) {
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" || parts[1] == "" {
+		return "", false
+	}
+	return parts[1], true
+}
+
+// hasIdentity reports whether verified claims name a user and one of the
+// known roles. Role checks downstream compare against fixed role names, so a
+// token without one must not be let through as some unnamed kind of user.
+func hasIdentity(claims *jwt.Claims) bool {
+	return claims.Subject != "" && user.Role(claims.Role).IsValid()
+}
+
+// withClaims adds the user ID ("sub") and role of verified claims to ctx.
+func withClaims(ctx context.Context, claims *jwt.Claims) context.Context {
+	ctx = context.WithValue(ctx, UserIDKey, claims.Subject)
+	return context.WithValue(ctx, UserRoleKey, claims.Role)
 }
 
 // RoleMiddleware restricts access to specific roles.
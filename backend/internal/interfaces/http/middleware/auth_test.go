@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"foodie/backend/pkg/jwt"
+)
+
+// stubVerifier returns fixed claims for any token.
+type stubVerifier struct {
+	claims *jwt.Claims
+	err    error
+}
+
+func (v stubVerifier) Verify(token string) (*jwt.Claims, error) {
+	return v.claims, v.err
+}
+
+func TestAuthMiddlewareRequiresKnownRole(t *testing.T) {
+	tests := []struct {
+		name       string
+		claims     jwt.Claims
+		wantStatus int
+	}{
+		{name: "customer", claims: jwt.Claims{Subject: "u1", Role: "user"}, wantStatus: http.StatusOK},
+		{name: "restaurant", claims: jwt.Claims{Subject: "u1", Role: "restaurant"}, wantStatus: http.StatusOK},
+		{name: "admin", claims: jwt.Claims{Subject: "u1", Role: "admin"}, wantStatus: http.StatusOK},
+		{name: "empty role", claims: jwt.Claims{Subject: "u1"}, wantStatus: http.StatusUnauthorized},
+		{name: "unknown role", claims: jwt.Claims{Subject: "u1", Role: "superuser"}, wantStatus: http.StatusUnauthorized},
+		{name: "internal actor role", claims: jwt.Claims{Subject: "u1", Role: "system"}, wantStatus: http.StatusUnauthorized},
+		{name: "role with different case", claims: jwt.Claims{Subject: "u1", Role: "Admin"}, wantStatus: http.StatusUnauthorized},
+		{name: "no subject", claims: jwt.Claims{Role: "admin"}, wantStatus: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := tt.claims
+			if got, want := hasIdentity(&claims), tt.wantStatus == http.StatusOK; got != want {
+				t.Fatalf("hasIdentity = %v, want %v", got, want)
+			}
+
+			var gotRole string
+			handler := AuthMiddleware(stubVerifier{claims: &claims})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotRole = GetUserRole(r)
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer token")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusOK && gotRole != claims.Role {
+				t.Fatalf("role in context %q, want %q", gotRole, claims.Role)
+			}
+		})
+	}
+}
+
+func TestOptionalAuthMiddlewareIgnoresUnknownRole(t *testing.T) {
+	var gotUserID string
+	handler := OptionalAuthMiddleware(stubVerifier{claims: &jwt.Claims{Subject: "u1", Role: "superuser"}})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotUserID = GetUserID(r)
+		}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK || gotUserID != "" {
+		t.Fatalf("status %d, user %q; want the request served anonymously", rec.Code, gotUserID)
+	}
+}
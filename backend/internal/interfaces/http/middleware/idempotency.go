@@ -0,0 +1,171 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"foodie/backend/internal/infrastructure/cache"
+	httputils "foodie/backend/pkg/utils/http"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header clients use to make a POST safely retryable.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on responses replayed from a previous request.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+	maxIdempotentBodySize   = 1 << 20 // 1 MiB
+	// idempotencyLockTTL bounds how long an in-flight request holds its key,
+	// so a crashed request does not block retries forever.
+	idempotencyLockTTL = 1 * time.Minute
+)
+
+// idempotencyRecord is what gets stored in cache for each Idempotency-Key.
+type idempotencyRecord struct {
+	Fingerprint string `json:"fingerprint"`
+	Completed   bool   `json:"completed"`
+	StatusCode  int    `json:"status_code,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// idempotencyRecorder captures the response while passing it through to the client.
+type idempotencyRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (rec *idempotencyRecorder) WriteHeader(code int) {
+	rec.statusCode = code
+	rec.ResponseWriter.WriteHeader(code)
+}
+
+func (rec *idempotencyRecorder) Write(b []byte) (int, error) {
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
+
+// IdempotencyMiddleware makes requests carrying an Idempotency-Key header safe to retry.
+//
+// The first request with a key claims it with SetNX, runs the handler and stores the
+// response for ttl. Retries with the same key and body get the stored response replayed.
+// Concurrent duplicates that arrive while the first is in flight receive 409 Conflict,
+// and reusing a key with a different body returns 422 Unprocessable Entity.
+// Keys are scoped per authenticated user, so it must run after AuthMiddleware.
+// Server errors (5xx) are not stored so the client can retry.
+func IdempotencyMiddleware(store cache.Cache, ttl time.Duration) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				httputils.BadRequest(w, "Idempotency-Key is too long", nil)
+				return
+			}
+
+			// Read the body to fingerprint it, then restore it for the handler
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxIdempotentBodySize+1))
+			if err != nil {
+				httputils.BadRequest(w, "Failed to read request body", err)
+				return
+			}
+			if len(body) > maxIdempotentBodySize {
+				httputils.Error(w, http.StatusRequestEntityTooLarge, "Request body too large", nil)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := r.Context()
+			cacheKey := "idempotency:" + GetUserID(r) + ":" + r.Method + ":" + r.URL.Path + ":" + key
+			fingerprint := requestFingerprint(r, body)
+
+			lock, _ := json.Marshal(idempotencyRecord{Fingerprint: fingerprint})
+			claimed, err := store.SetNX(ctx, cacheKey, lock, idempotencyLockTTL)
+			if err != nil {
+				httputils.InternalServerError(w, "Idempotency store unavailable", err)
+				return
+			}
+
+			if !claimed {
+				replayIdempotentResponse(w, r, store, cacheKey, fingerprint)
+				return
+			}
+
+			rec := &idempotencyRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			// Release the key on server errors so the client can retry
+			if rec.statusCode >= http.StatusInternalServerError {
+				_ = store.Delete(ctx, cacheKey)
+				return
+			}
+
+			record, err := json.Marshal(idempotencyRecord{
+				Fingerprint: fingerprint,
+				Completed:   true,
+				StatusCode:  rec.statusCode,
+				ContentType: rec.Header().Get("Content-Type"),
+				Body:        rec.body.Bytes(),
+			})
+			if err != nil {
+				_ = store.Delete(ctx, cacheKey)
+				return
+			}
+			_ = store.Set(ctx, cacheKey, record, ttl)
+		})
+	}
+}
+
+// replayIdempotentResponse answers a request whose key has already been claimed.
+func replayIdempotentResponse(w http.ResponseWriter, r *http.Request, store cache.Cache, cacheKey, fingerprint string) {
+	data, err := store.Get(r.Context(), cacheKey)
+	if err != nil {
+		httputils.InternalServerError(w, "Idempotency store unavailable", err)
+		return
+	}
+
+	var record idempotencyRecord
+	if data == nil || json.Unmarshal(data, &record) != nil {
+		// The key expired or was released between SetNX and Get
+		httputils.Conflict(w, "A request with this Idempotency-Key is being processed, retry later", nil)
+		return
+	}
+
+	if record.Fingerprint != fingerprint {
+		httputils.Error(w, http.StatusUnprocessableEntity, "Idempotency-Key was already used with a different request", nil)
+		return
+	}
+
+	if !record.Completed {
+		httputils.Conflict(w, "A request with this Idempotency-Key is being processed, retry later", nil)
+		return
+	}
+
+	if record.ContentType != "" {
+		w.Header().Set("Content-Type", record.ContentType)
+	}
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(record.StatusCode)
+	w.Write(record.Body)
+}
+
+// requestFingerprint hashes the parts of a request that must match for a replay.
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method))
+	h.Write([]byte{0})
+	h.Write([]byte(r.URL.Path))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
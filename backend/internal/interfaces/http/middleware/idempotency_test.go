@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"foodie/backend/internal/infrastructure/cache"
+	"foodie/backend/pkg/jwt"
+)
+
+// countingHandler answers with the status it is given and counts how often it ran.
+type countingHandler struct {
+	calls  atomic.Int32
+	status atomic.Int32
+}
+
+func (h *countingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n := h.calls.Add(1)
+	body, _ := io.ReadAll(r.Body)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(int(h.status.Load()))
+	fmt.Fprintf(w, `{"call":%d,"body":%q}`, n, body)
+}
+
+func newIdempotent(t *testing.T, next http.Handler) (http.Handler, *cache.MemoryCache) {
+	t.Helper()
+	store := cache.NewMemoryCache()
+	t.Cleanup(func() { store.Close() })
+	return IdempotencyMiddleware(store, time.Hour)(next), store
+}
+
+// idempotentRequest sends a POST to /api/v1/orders as userID with the given key.
+func idempotentRequest(h http.Handler, userID, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	req = req.WithContext(withClaims(req.Context(), &jwt.Claims{Subject: userID, Role: "user"}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotencyReplaysCompletedResponse(t *testing.T) {
+	next := &countingHandler{}
+	next.status.Store(http.StatusCreated)
+	h, _ := newIdempotent(t, next)
+
+	first := idempotentRequest(h, "u1", "key-1", `{"item":1}`)
+	second := idempotentRequest(h, "u1", "key-1", `{"item":1}`)
+
+	if next.calls.Load() != 1 {
+		t.Fatalf("handler ran %d times, want once", next.calls.Load())
+	}
+	if second.Code != http.StatusCreated || second.Body.String() != first.Body.String() {
+		t.Fatalf("replay got %d %s, want %d %s", second.Code, second.Body, first.Code, first.Body)
+	}
+	if second.Header().Get(IdempotentReplayedHeader) != "true" || first.Header().Get(IdempotentReplayedHeader) != "" {
+		t.Fatalf("%s header: first %q, replay %q", IdempotentReplayedHeader,
+			first.Header().Get(IdempotentReplayedHeader), second.Header().Get(IdempotentReplayedHeader))
+	}
+	if got := second.Header().Get("Content-Type"); got != "application/json" {
+		t.Fatalf("replayed Content-Type %q, want application/json", got)
+	}
+}
+
+func TestIdempotencyWithoutKeyPassesThrough(t *testing.T) {
+	next := &countingHandler{}
+	next.status.Store(http.StatusCreated)
+	h, _ := newIdempotent(t, next)
+
+	idempotentRequest(h, "u1", "", `{}`)
+	idempotentRequest(h, "u1", "", `{}`)
+	if next.calls.Load() != 2 {
+		t.Fatalf("handler ran %d times, want twice", next.calls.Load())
+	}
+}
+
+func TestIdempotencyRejectsInFlightDuplicate(t *testing.T) {
+	entered, release := make(chan struct{}), make(chan struct{})
+	var calls atomic.Int32
+	h, store := newIdempotent(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		close(entered)
+		<-release
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- idempotentRequest(h, "u1", "key-1", `{"item":1}`) }()
+	<-entered
+
+	// The first request holds the key with an incomplete record while it runs
+	data, err := store.Get(context.Background(), "idempotency:u1:POST:/api/v1/orders:key-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lock idempotencyRecord
+	if err := json.Unmarshal(data, &lock); err != nil || lock.Completed || lock.Fingerprint == "" {
+		t.Fatalf("lock record %+v, %v; want an incomplete record with a fingerprint", lock, err)
+	}
+
+	if rec := idempotentRequest(h, "u1", "key-1", `{"item":1}`); rec.Code != http.StatusConflict {
+		t.Fatalf("duplicate in flight got %d, want %d", rec.Code, http.StatusConflict)
+	}
+
+	close(release)
+	if rec := <-done; rec.Code != http.StatusCreated {
+		t.Fatalf("first request got %d, want %d", rec.Code, http.StatusCreated)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("handler ran %d times, want once", calls.Load())
+	}
+}
+
+func TestIdempotencyRejectsKeyReusedWithDifferentBody(t *testing.T) {
+	next := &countingHandler{}
+	next.status.Store(http.StatusCreated)
+	h, _ := newIdempotent(t, next)
+
+	idempotentRequest(h, "u1", "key-1", `{"item":1}`)
+	rec := idempotentRequest(h, "u1", "key-1", `{"item":2}`)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("got %d, want %d", rec.Code, http.StatusUnprocessableEntity)
+	}
+	if next.calls.Load() != 1 {
+		t.Fatalf("handler ran %d times, want once", next.calls.Load())
+	}
+}
+
+func TestIdempotencyDoesNotStoreServerErrors(t *testing.T) {
+	next := &countingHandler{}
+	next.status.Store(http.StatusInternalServerError)
+	h, _ := newIdempotent(t, next)
+
+	if rec := idempotentRequest(h, "u1", "key-1", `{"item":1}`); rec.Code != http.StatusInternalServerError {
+		t.Fatalf("first attempt got %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+
+	// The retry runs the handler again, and its success is what gets stored
+	next.status.Store(http.StatusCreated)
+	if rec := idempotentRequest(h, "u1", "key-1", `{"item":1}`); rec.Code != http.StatusCreated || rec.Header().Get(IdempotentReplayedHeader) != "" {
+		t.Fatalf("retry got %d (replayed %q), want a fresh %d", rec.Code, rec.Header().Get(IdempotentReplayedHeader), http.StatusCreated)
+	}
+	if rec := idempotentRequest(h, "u1", "key-1", `{"item":1}`); rec.Code != http.StatusCreated || rec.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Fatalf("third attempt got %d (replayed %q), want the stored %d", rec.Code, rec.Header().Get(IdempotentReplayedHeader), http.StatusCreated)
+	}
+	if next.calls.Load() != 2 {
+		t.Fatalf("handler ran %d times, want twice", next.calls.Load())
+	}
+}
+
+func TestIdempotencyKeysAreScopedPerUser(t *testing.T) {
+	next := &countingHandler{}
+	next.status.Store(http.StatusCreated)
+	h, _ := newIdempotent(t, next)
+
+	first := idempotentRequest(h, "u1", "key-1", `{"item":1}`)
+	// Another user picking the same key neither sees u1's response nor clashes with it
+	other := idempotentRequest(h, "u2", "key-1", `{"item":2}`)
+
+	if other.Code != http.StatusCreated || other.Header().Get(IdempotentReplayedHeader) != "" {
+		t.Fatalf("other user got %d (replayed %q), want a fresh %d", other.Code, other.Header().Get(IdempotentReplayedHeader), http.StatusCreated)
+	}
+	if other.Body.String() == first.Body.String() {
+		t.Fatalf("other user was served %s, the first user's response", other.Body)
+	}
+	if next.calls.Load() != 2 {
+		t.Fatalf("handler ran %d times, want twice", next.calls.Load())
+	}
+}
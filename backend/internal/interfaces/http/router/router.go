@@ -2,7 +2,10 @@ package router
 
 import (
 	"net/http"
+	"strings"
+	"sync"
 
+	"foodie/backend/internal/infrastructure/cache"
 	"foodie/backend/internal/interfaces/http/controller"
 	"foodie/backend/internal/interfaces/http/middleware"
 	"foodie/backend/pkg/logger"
@@ -10,26 +13,48 @@ import (
 
 // Router sets up HTTP routes and delegates to controllers.
 type Router struct {
-	mux               *http.ServeMux
-	logger            *logger.Logger
-	healthController  *controller.HealthController
-	orderController   *controller.OrderController
-	productController *controller.ProductController
+	mux                  *http.ServeMux
+	logger               *logger.Logger
+	cache                cache.Cache              // Backs request idempotency
+	tokenVerifier        middleware.TokenVerifier // Checks access tokens on private routes
+	healthController     *controller.HealthController
+	orderController      *controller.OrderController
+	productController    *controller.ProductController
+	menuController       *controller.MenuController
+	searchController     *controller.SearchController
+	restaurantController *controller.RestaurantController
+	authController       *controller.AuthController
+
+	handlersByPattern map[string]*methodHandlers // Pattern -> method -> handler, shared by all groups
+	routesMu          sync.Mutex
 }
 
 // NewRouter creates a new HTTP router with controllers and logger.
 func NewRouter(
 	logger *logger.Logger,
+	cache cache.Cache,
+	tokenVerifier middleware.TokenVerifier,
 	healthController *controller.HealthController,
 	orderController *controller.OrderController,
 	productController *controller.ProductController,
+	menuController *controller.MenuController,
+	searchController *controller.SearchController,
+	restaurantController *controller.RestaurantController,
+	authController *controller.AuthController,
 ) *Router {
 	return &Router{
-		mux:               http.NewServeMux(),
-		logger:            logger,
-		healthController:  healthController,
-		orderController:   orderController,
-		productController: productController,
+		mux:                  http.NewServeMux(),
+		logger:               logger,
+		cache:                cache,
+		tokenVerifier:        tokenVerifier,
+		healthController:     healthController,
+		orderController:      orderController,
+		productController:    productController,
+		menuController:       menuController,
+		searchController:     searchController,
+		restaurantController: restaurantController,
+		authController:       authController,
+		handlersByPattern:    make(map[string]*methodHandlers),
 	}
 }
 
@@ -51,10 +76,17 @@ func (r *Router) SetupRoutes() {
 	r.setupPublicRoutes(public)
 
 	// Private routes (authentication required)
-	private := r.RouteGroup("/api/v1", append(globalMiddleware, middleware.AuthMiddleware)...)
+	private := r.RouteGroup("/api/v1", append(globalMiddleware, middleware.AuthMiddleware(r.tokenVerifier))...)
 	r.setupPrivateRoutes(private)
 }
 
+// Mount serves handler for every path under prefix, outside the route groups,
+// e.g. uploaded files. The prefix is stripped before handler sees the path.
+func (r *Router) Mount(prefix string, handler http.Handler) {
+	prefix = strings.TrimSuffix(prefix, "/")
+	r.mux.Handle(prefix+"/", http.StripPrefix(prefix, handler))
+}
+
 // ServeHTTP implements http.Handler interface.
 func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	r.mux.ServeHTTP(w, req)
@@ -16,22 +16,19 @@ type methodHandlers struct {
 
 // RouteGroup represents a group of routes with shared middleware.
 type RouteGroup struct {
-	prefix            string
-	middleware        []middleware.Middleware
-	mux               *http.ServeMux
-	parent            *Router
-	handlersByPattern map[string]*methodHandlers // Pattern -> method -> handler
-	mu                sync.Mutex
+	prefix     string
+	middleware []middleware.Middleware
+	mux        *http.ServeMux
+	parent     *Router
 }
 
 // RouteGroup creates a new route group with a prefix and optional middleware.
 func (r *Router) RouteGroup(prefix string, mw ...middleware.Middleware) *RouteGroup {
 	return &RouteGroup{
-		prefix:            prefix,
-		middleware:        mw,
-		mux:               r.mux,
-		parent:            r,
-		handlersByPattern: make(map[string]*methodHandlers),
+		prefix:     prefix,
+		middleware: mw,
+		mux:        r.mux,
+		parent:     r,
 	}
 }
 
@@ -50,6 +47,11 @@ func (rg *RouteGroup) PUT(pattern string, handler http.HandlerFunc) {
 	rg.Handle(http.MethodPut, pattern, handler)
 }
 
+// PATCH registers a PATCH route in the group.
+func (rg *RouteGroup) PATCH(pattern string, handler http.HandlerFunc) {
+	rg.Handle(http.MethodPatch, pattern, handler)
+}
+
 // DELETE registers a DELETE route in the group.
 func (rg *RouteGroup) DELETE(pattern string, handler http.HandlerFunc) {
 	rg.Handle(http.MethodDelete, pattern, handler)
@@ -65,16 +67,17 @@ func (rg *RouteGroup) Handle(method, pattern string, handler http.HandlerFunc) {
 		h = rg.middleware[i](h)
 	}
 
-	// Store handler in map by pattern and method
-	rg.mu.Lock()
-	mh, exists := rg.handlersByPattern[fullPattern]
+	// Store handler in map by pattern and method. The map lives on the Router so
+	// groups can share a path, e.g. a public GET and a private POST on /api/v1/products.
+	rg.parent.routesMu.Lock()
+	mh, exists := rg.parent.handlersByPattern[fullPattern]
 	if !exists {
 		mh = &methodHandlers{
 			handlers: make(map[string]http.Handler),
 		}
-		rg.handlersByPattern[fullPattern] = mh
+		rg.parent.handlersByPattern[fullPattern] = mh
 	}
-	rg.mu.Unlock()
+	rg.parent.routesMu.Unlock()
 
 	// Add handler for this method
 	mh.mu.Lock()
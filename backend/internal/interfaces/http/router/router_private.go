@@ -3,15 +3,72 @@ package router
 import (
 	"net/http"
 	"strings"
+	"time"
+
+	"foodie/backend/internal/interfaces/http/middleware"
+	"foodie/backend/pkg/config"
 )
 
 // setupPrivateRoutes registers private routes that require authentication.
 func (r *Router) setupPrivateRoutes(private *RouteGroup) {
+	restaurantStaff := middleware.RoleMiddleware("restaurant", "admin")
+
 	// Order routes (require authentication)
 	// Use single handler for GET /orders that handles both list and get-by-ID
 	private.GET("/orders", r.handleOrders)
-	// POST /api/v1/orders - Create order
-	private.POST("/orders", r.orderController.CreateOrder)
+	private.GET("/orders/", r.handleOrders)
+	// POST /api/v1/orders - Create order (retry-safe with Idempotency-Key header)
+	idempotencyTTL := time.Duration(config.GetInt("IDEMPOTENCY_TTL_HOURS", 24)) * time.Hour
+	idempotent := middleware.IdempotencyMiddleware(r.cache, idempotencyTTL)
+	private.POST("/orders", idempotent(http.HandlerFunc(r.orderController.CreateOrder)).ServeHTTP)
+	// POST /api/v1/orders/quote - Price a basket without placing an order
+	private.POST("/orders/quote", r.orderController.QuoteOrder)
+	// PATCH /api/v1/orders/{id}/status - Move order through its status lifecycle (restaurant owners and admins)
+	private.PATCH("/orders/{id}/status", restaurantStaff(http.HandlerFunc(r.orderController.UpdateOrderStatus)).ServeHTTP)
+	// GET /api/v1/orders/{id}/timeline - Status history of an order
+	private.GET("/orders/{id}/timeline", r.orderController.GetOrderTimeline)
+	// POST /api/v1/orders/{id}/cancel - Cancel order (refunds any payment taken)
+	private.POST("/orders/{id}/cancel", r.orderController.CancelOrder)
+
+	// Restaurant management (restaurant owners and admins)
+	// POST /api/v1/restaurants - Register a restaurant; restaurant accounts become its owner
+	private.POST("/restaurants", restaurantStaff(http.HandlerFunc(r.restaurantController.CreateRestaurant)).ServeHTTP)
+	// PUT /api/v1/restaurants/{id} - Replace details, status and opening hours
+	private.PUT("/restaurants/{id}", restaurantStaff(http.HandlerFunc(r.restaurantController.UpdateRestaurant)).ServeHTTP)
+	// DELETE /api/v1/restaurants/{id} - Soft delete a restaurant
+	private.DELETE("/restaurants/{id}", restaurantStaff(http.HandlerFunc(r.restaurantController.DeleteRestaurant)).ServeHTTP)
+
+	// Restaurant order queue (restaurant owners and admins)
+	// GET /api/v1/restaurants/{id}/orders - Restaurant's orders, e.g. ?status=pending
+	private.GET("/restaurants/{id}/orders", restaurantStaff(http.HandlerFunc(r.orderController.ListRestaurantOrders)).ServeHTTP)
+	// POST /api/v1/restaurants/{id}/orders/{orderId}/accept - Confirm with a preparation time
+	private.POST("/restaurants/{id}/orders/{orderId}/accept", restaurantStaff(http.HandlerFunc(r.orderController.AcceptOrder)).ServeHTTP)
+	// POST /api/v1/restaurants/{id}/orders/{orderId}/reject - Cancel and refund
+	private.POST("/restaurants/{id}/orders/{orderId}/reject", restaurantStaff(http.HandlerFunc(r.orderController.RejectOrder)).ServeHTTP)
+
+	// Product management (restaurant owners and admins)
+	// POST /api/v1/products - Add a product to a restaurant's menu
+	private.POST("/products", restaurantStaff(http.HandlerFunc(r.productController.CreateProduct)).ServeHTTP)
+	// PUT /api/v1/products/{id} - Replace a product's name and price
+	private.PUT("/products/{id}", restaurantStaff(http.HandlerFunc(r.productController.UpdateProduct)).ServeHTTP)
+	// DELETE /api/v1/products/{id} - Soft delete a product
+	private.DELETE("/products/{id}", restaurantStaff(http.HandlerFunc(r.productController.DeleteProduct)).ServeHTTP)
+	// PUT /api/v1/products/{id}/stock - Set stock tracking (unlimited, daily quota or count)
+	private.PUT("/products/{id}/stock", restaurantStaff(http.HandlerFunc(r.productController.SetProductStock)).ServeHTTP)
+	// PUT /api/v1/products/{id}/image - Upload a product image (multipart field "image")
+	private.PUT("/products/{id}/image", restaurantStaff(http.HandlerFunc(r.productController.UploadProductImage)).ServeHTTP)
+	// POST /api/v1/products/{id}/prices - Schedule a price change
+	private.POST("/products/{id}/prices", restaurantStaff(http.HandlerFunc(r.productController.SchedulePriceChange)).ServeHTTP)
+	// GET /api/v1/products/{id}/prices - Price history, e.g. ?at=2024-05-01T12:00:00Z
+	private.GET("/products/{id}/prices", restaurantStaff(http.HandlerFunc(r.productController.GetPriceHistory)).ServeHTTP)
+
+	// Menu categories (restaurant owners and admins)
+	// POST /api/v1/restaurants/{id}/categories - Add a category to a restaurant's menu
+	private.POST("/restaurants/{id}/categories", restaurantStaff(http.HandlerFunc(r.menuController.CreateCategory)).ServeHTTP)
+	// PUT /api/v1/categories/{id} - Rename, reorder or hide a category
+	private.PUT("/categories/{id}", restaurantStaff(http.HandlerFunc(r.menuController.UpdateCategory)).ServeHTTP)
+	// DELETE /api/v1/categories/{id} - Delete a category; its products become uncategorized
+	private.DELETE("/categories/{id}", restaurantStaff(http.HandlerFunc(r.menuController.DeleteCategory)).ServeHTTP)
 }
 
 // handleOrders routes GET requests to /api/v1/orders
@@ -58,6 +58,22 @@ func (r *Router) setupPublicRoutes(public *RouteGroup) {
 		})
 	}
 
+	// Accounts: both return an access token to send as "Authorization: Bearer <token>"
+	// POST /api/v1/auth/register - Create a customer or restaurant account
+	public.POST("/api/v1/auth/register", r.authController.Register)
+	// POST /api/v1/auth/login - Sign in with email and password
+	public.POST("/api/v1/auth/login", r.authController.Login)
+
 	// Public product listing (anyone can view products)
 	public.GET("/api/v1/products", r.productController.ListProducts)
+	// GET /api/v1/products/{id} - Product detail, served from cache when warm
+	public.GET("/api/v1/products/{id}", r.productController.GetProduct)
+	// GET /api/v1/restaurants - Restaurants by name, e.g. ?name=pho&status=open
+	public.GET("/api/v1/restaurants", r.restaurantController.ListRestaurants)
+	// GET /api/v1/restaurants/{id} - Restaurant details, with whether it is open now
+	public.GET("/api/v1/restaurants/{id}", r.restaurantController.GetRestaurant)
+	// GET /api/v1/restaurants/{id}/menu - Visible categories with their products
+	public.GET("/api/v1/restaurants/{id}/menu", r.menuController.GetMenu)
+	// GET /api/v1/search?q= - Full-text product search with prefix matching
+	public.GET("/api/v1/search", r.searchController.Search)
 }
@@ -1,4 +1,5 @@
 -- Sync missing columns for products table
 -- This migration was auto-generated by schema-sync tool
 
-ALTER TABLE products ADD COLUMN IF NOT EXISTS product_image VARCHAR(255) NOT NULL DEFAULT NULL;
+-- Products without an uploaded image have no URL, so the column is nullable
+ALTER TABLE products ADD COLUMN IF NOT EXISTS product_image VARCHAR(255) NULL;
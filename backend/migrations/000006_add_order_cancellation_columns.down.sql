@@ -0,0 +1,9 @@
+-- Remove payment state and cancellation columns from orders
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS payment_id,
+    DROP COLUMN IF EXISTS payment_status,
+    DROP COLUMN IF EXISTS cancelled_by,
+    DROP COLUMN IF EXISTS cancelled_by_role,
+    DROP COLUMN IF EXISTS cancellation_reason,
+    DROP COLUMN IF EXISTS cancellation_note,
+    DROP COLUMN IF EXISTS cancelled_at;
@@ -0,0 +1,9 @@
+-- Track payment state and cancellation details on orders
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS payment_id VARCHAR(255);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS payment_status VARCHAR(50) NOT NULL DEFAULT 'unpaid';
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS cancelled_by VARCHAR(36);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS cancelled_by_role VARCHAR(50);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS cancellation_reason VARCHAR(50);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS cancellation_note TEXT;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMP;
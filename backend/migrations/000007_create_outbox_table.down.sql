@@ -0,0 +1,6 @@
+-- Drop indexes first
+DROP INDEX IF EXISTS idx_outbox_unpublished;
+DROP INDEX IF EXISTS idx_outbox_aggregate_id;
+
+-- Drop outbox table
+DROP TABLE IF EXISTS outbox;
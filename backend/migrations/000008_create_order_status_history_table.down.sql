@@ -0,0 +1,5 @@
+-- Drop indexes first
+DROP INDEX IF EXISTS idx_order_status_history_order_id;
+
+-- Drop order_status_history table
+DROP TABLE IF EXISTS order_status_history;
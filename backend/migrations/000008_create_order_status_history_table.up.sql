@@ -0,0 +1,20 @@
+-- Create order_status_history table: one row per status change
+CREATE TABLE IF NOT EXISTS order_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    order_id VARCHAR(36) NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    from_status VARCHAR(50),
+    to_status VARCHAR(50) NOT NULL,
+    actor_id VARCHAR(36),
+    actor_role VARCHAR(50),
+    note TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_history_order_id ON order_status_history(order_id, created_at);
+
+-- Backfill what we know for existing orders: creation and current status
+INSERT INTO order_status_history (order_id, from_status, to_status, actor_id, note, created_at)
+SELECT id, NULL, 'pending', user_id, 'backfilled', created_at FROM orders;
+
+INSERT INTO order_status_history (order_id, from_status, to_status, note, created_at)
+SELECT id, 'pending', status, 'backfilled', updated_at FROM orders WHERE status <> 'pending';
@@ -0,0 +1,4 @@
+-- Drop payments table
+DROP INDEX IF EXISTS idx_payments_gateway_payment_id;
+DROP INDEX IF EXISTS idx_payments_status;
+DROP TABLE IF EXISTS payments;
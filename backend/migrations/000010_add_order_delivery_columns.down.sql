@@ -0,0 +1,4 @@
+-- Remove delivery quote columns from orders
+ALTER TABLE orders DROP COLUMN IF EXISTS estimated_delivery_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS distance_km;
+ALTER TABLE orders DROP COLUMN IF EXISTS delivery_fee;
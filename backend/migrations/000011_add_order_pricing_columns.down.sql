@@ -0,0 +1,6 @@
+-- Remove price breakdown columns from orders
+ALTER TABLE orders DROP COLUMN IF EXISTS promo_code;
+ALTER TABLE orders DROP COLUMN IF EXISTS tax;
+ALTER TABLE orders DROP COLUMN IF EXISTS service_fee;
+ALTER TABLE orders DROP COLUMN IF EXISTS discount;
+ALTER TABLE orders DROP COLUMN IF EXISTS subtotal;
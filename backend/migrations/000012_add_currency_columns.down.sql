@@ -0,0 +1,3 @@
+-- Remove currency columns
+ALTER TABLE products DROP COLUMN IF EXISTS currency;
+ALTER TABLE orders DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,3 @@
+-- Drop order listing indexes
+DROP INDEX IF EXISTS idx_orders_user_id_created_at;
+DROP INDEX IF EXISTS idx_orders_restaurant_id_created_at;
@@ -0,0 +1,3 @@
+-- Indexes backing filtered order listings for restaurants and admins
+CREATE INDEX IF NOT EXISTS idx_orders_restaurant_id_created_at ON orders(restaurant_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_orders_user_id_created_at ON orders(user_id, created_at DESC);
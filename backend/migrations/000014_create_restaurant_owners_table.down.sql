@@ -0,0 +1,3 @@
+-- Drop restaurant_owners table
+DROP INDEX IF EXISTS idx_restaurant_owners_user_id;
+DROP TABLE IF EXISTS restaurant_owners;
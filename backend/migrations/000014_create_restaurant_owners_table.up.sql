@@ -0,0 +1,9 @@
+-- Create restaurant_owners table: which user accounts may manage a restaurant
+CREATE TABLE IF NOT EXISTS restaurant_owners (
+    restaurant_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (restaurant_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_restaurant_owners_user_id ON restaurant_owners(user_id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_products_restaurant_active;
+
+ALTER TABLE products DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE products DROP COLUMN IF EXISTS updated_at;
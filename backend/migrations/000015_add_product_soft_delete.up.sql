@@ -0,0 +1,5 @@
+-- Track edits and soft deletes; deleted products stay referenced by past orders
+ALTER TABLE products ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP NULL;
+
+CREATE INDEX IF NOT EXISTS idx_products_restaurant_active ON products(restaurant_id) WHERE deleted_at IS NULL;
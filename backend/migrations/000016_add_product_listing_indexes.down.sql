@@ -0,0 +1,3 @@
+-- Drop product listing indexes
+DROP INDEX IF EXISTS idx_products_created_at_active;
+DROP INDEX IF EXISTS idx_products_price_active;
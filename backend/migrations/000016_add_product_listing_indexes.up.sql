@@ -0,0 +1,3 @@
+-- Indexes backing the sorted product catalogue; deleted products are never listed
+CREATE INDEX IF NOT EXISTS idx_products_price_active ON products(price) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_products_created_at_active ON products(created_at DESC) WHERE deleted_at IS NULL;
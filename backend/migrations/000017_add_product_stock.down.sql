@@ -0,0 +1,7 @@
+-- Remove product stock columns
+DROP INDEX IF EXISTS idx_products_daily_stock;
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS chk_products_stock_available;
+ALTER TABLE products DROP COLUMN IF EXISTS daily_quota;
+ALTER TABLE products DROP COLUMN IF EXISTS stock_available;
+ALTER TABLE products DROP COLUMN IF EXISTS stock_mode;
@@ -0,0 +1,18 @@
+-- Per-product stock: unlimited, a daily quota refilled nightly, or a hand-managed count
+ALTER TABLE products ADD COLUMN IF NOT EXISTS stock_mode VARCHAR(16) NOT NULL DEFAULT 'unlimited';
+ALTER TABLE products ADD COLUMN IF NOT EXISTS stock_available INT NOT NULL DEFAULT 0;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS daily_quota INT NOT NULL DEFAULT 0;
+
+-- Reservations decrement in place; never let a tracked product go negative
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint
+        WHERE conname = 'chk_products_stock_available' AND conrelid = 'products'::regclass
+    ) THEN
+        ALTER TABLE products ADD CONSTRAINT chk_products_stock_available
+            CHECK (stock_mode = 'unlimited' OR stock_available >= 0);
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_products_daily_stock ON products(stock_mode) WHERE stock_mode = 'daily';
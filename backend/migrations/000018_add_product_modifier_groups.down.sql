@@ -0,0 +1,2 @@
+-- Remove product modifier groups
+ALTER TABLE products DROP COLUMN IF EXISTS modifier_groups;
@@ -0,0 +1,2 @@
+-- Modifier groups (sizes, toppings, add-ons) offered with each product, as JSON
+ALTER TABLE products ADD COLUMN IF NOT EXISTS modifier_groups JSONB NOT NULL DEFAULT '[]';
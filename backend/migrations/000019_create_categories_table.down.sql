@@ -0,0 +1,6 @@
+-- Remove categories
+DROP INDEX IF EXISTS idx_products_category_id;
+ALTER TABLE products DROP COLUMN IF EXISTS category_id;
+
+DROP INDEX IF EXISTS idx_categories_restaurant_id;
+DROP TABLE IF EXISTS categories;
@@ -0,0 +1,18 @@
+-- Create categories table: sections of a restaurant's menu
+CREATE TABLE IF NOT EXISTS categories (
+    id VARCHAR(36) PRIMARY KEY,
+    restaurant_id VARCHAR(255) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    sort_order INT NOT NULL DEFAULT 0,
+    visible BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_categories_restaurant_id ON categories(restaurant_id, sort_order);
+
+-- Products belong to at most one category; deleting a category uncategorizes them
+ALTER TABLE products ADD COLUMN IF NOT EXISTS category_id VARCHAR(36) NULL
+    REFERENCES categories(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_products_category_id ON products(category_id);
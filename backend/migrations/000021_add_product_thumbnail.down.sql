@@ -0,0 +1,2 @@
+-- Remove product thumbnails; product_image stays nullable
+ALTER TABLE products DROP COLUMN IF EXISTS product_thumbnail;
@@ -0,0 +1,7 @@
+-- Databases that applied the original 000005 on an empty table got a NOT NULL
+-- product_image column, which made every insert without an image fail
+ALTER TABLE products ALTER COLUMN product_image DROP NOT NULL;
+ALTER TABLE products ALTER COLUMN product_image DROP DEFAULT;
+
+-- Thumbnail generated from the uploaded product image
+ALTER TABLE products ADD COLUMN IF NOT EXISTS product_thumbnail VARCHAR(255) NULL;
@@ -0,0 +1,6 @@
+-- Remove availability windows
+ALTER TABLE categories DROP COLUMN IF EXISTS availability_slots;
+ALTER TABLE categories DROP COLUMN IF EXISTS availability;
+
+ALTER TABLE products DROP COLUMN IF EXISTS availability_slots;
+ALTER TABLE products DROP COLUMN IF EXISTS availability;
@@ -0,0 +1,13 @@
+-- Availability windows: when products and categories can be ordered.
+-- availability holds the windows as entered, e.g.
+--   [{"days": ["mon", "tue"], "start": "07:00", "end": "11:00"}]
+-- availability_slots holds the same windows expanded to one same-day range
+-- per weekday, in minutes, with overnight windows split at midnight, e.g.
+--   [{"day": 1, "start_minute": 420, "end_minute": 660}, ...]
+-- so listings can filter without knowing about overnight windows.
+-- An empty array means always available.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS availability JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE products ADD COLUMN IF NOT EXISTS availability_slots JSONB NOT NULL DEFAULT '[]';
+
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS availability JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS availability_slots JSONB NOT NULL DEFAULT '[]';
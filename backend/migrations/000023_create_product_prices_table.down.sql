@@ -0,0 +1,3 @@
+-- Remove price history
+DROP INDEX IF EXISTS idx_product_prices_pending;
+DROP TABLE IF EXISTS product_prices;
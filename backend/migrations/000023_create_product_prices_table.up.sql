@@ -0,0 +1,25 @@
+-- Price history: every price a product has had or is scheduled to have.
+-- The latest row with effective_from in the past is the current price;
+-- products.price holds a copy, refreshed when the scheduler applies a change
+-- (applied_at). Times are TIMESTAMPTZ so they compare correctly with NOW().
+CREATE TABLE IF NOT EXISTS product_prices (
+    id VARCHAR(36) PRIMARY KEY,
+    product_id VARCHAR(255) NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    price DECIMAL(10, 2) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    effective_from TIMESTAMPTZ NOT NULL,
+    created_by VARCHAR(255) NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    applied_at TIMESTAMPTZ NULL,
+    UNIQUE (product_id, effective_from)
+);
+
+-- Finds the due changes the scheduler has not applied yet
+CREATE INDEX IF NOT EXISTS idx_product_prices_pending ON product_prices(effective_from)
+    WHERE applied_at IS NULL;
+
+-- Earlier prices were never recorded; history starts with the current one
+INSERT INTO product_prices (id, product_id, price, currency, effective_from, created_at, applied_at)
+SELECT gen_random_uuid()::text, id, price, currency, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM products
+ON CONFLICT DO NOTHING;
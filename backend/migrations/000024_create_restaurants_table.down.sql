@@ -0,0 +1,3 @@
+-- Drop restaurants table
+DROP INDEX IF EXISTS idx_restaurants_status_active;
+DROP TABLE IF EXISTS restaurants;
@@ -0,0 +1,19 @@
+-- Create restaurants table. Orders, products and categories already refer to
+-- restaurants by ID, so no foreign keys are added for rows that predate it.
+-- Owners are listed in restaurant_owners (000014), the one ownership model.
+CREATE TABLE IF NOT EXISTS restaurants (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    address VARCHAR(500) NOT NULL,
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    -- Weekly windows orders are accepted in, e.g. [{"days":["mon"],"start":"09:00","end":"22:00"}]
+    opening_hours JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_restaurants_status_active ON restaurants(status) WHERE deleted_at IS NULL;
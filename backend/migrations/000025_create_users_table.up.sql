@@ -0,0 +1,12 @@
+-- Create users table: accounts that sign in to the API. Emails are stored
+-- normalized (trimmed, lower-case) so each address has one account.
+CREATE TABLE IF NOT EXISTS users (
+    id VARCHAR(36) PRIMARY KEY,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'user',
+    -- Encoded hash with its scheme, e.g. $argon2id$v=19$m=19456,t=2,p=1$<salt>$<key>
+    password_hash VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,5 @@
+-- Remove restaurant full-text search
+DROP INDEX IF EXISTS idx_restaurants_search_vector;
+DROP TRIGGER IF EXISTS trg_restaurants_search_vector ON restaurants;
+DROP FUNCTION IF EXISTS restaurants_search_vector_update();
+ALTER TABLE restaurants DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,24 @@
+-- Full-text search over restaurants, matched like products (000020) with the
+-- 'simple' configuration. Names rank above addresses.
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS search_vector TSVECTOR;
+
+CREATE OR REPLACE FUNCTION restaurants_search_vector_update() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('simple', COALESCE(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('simple', COALESCE(NEW.address, '')), 'B');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_restaurants_search_vector ON restaurants;
+CREATE TRIGGER trg_restaurants_search_vector
+    BEFORE INSERT OR UPDATE OF name, address ON restaurants
+    FOR EACH ROW EXECUTE FUNCTION restaurants_search_vector_update();
+
+-- Backfill existing rows
+UPDATE restaurants SET search_vector =
+    setweight(to_tsvector('simple', COALESCE(name, '')), 'A') ||
+    setweight(to_tsvector('simple', COALESCE(address, '')), 'B');
+
+CREATE INDEX IF NOT EXISTS idx_restaurants_search_vector ON restaurants USING GIN (search_vector);
@@ -0,0 +1,2 @@
+-- Remove prep time column
+ALTER TABLE orders DROP COLUMN IF EXISTS prep_time_minutes;
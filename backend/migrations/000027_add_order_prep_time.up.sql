@@ -0,0 +1,3 @@
+-- Preparation time the restaurant committed to when accepting an order.
+-- Databases migrated before this column had its own migration already have it.
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS prep_time_minutes INT;
@@ -53,6 +53,17 @@ func GetInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// GetFloat returns environment variable as float64, with optional default.
+func GetFloat(key string, defaultValue float64) float64 {
+	if v := os.Getenv(key); v != "" {
+		var result float64
+		if _, err := fmt.Sscanf(v, "%g", &result); err == nil {
+			return result
+		}
+	}
+	return defaultValue
+}
+
 // GetBool returns environment variable as bool, with optional default.
 func GetBool(key string, defaultValue bool) bool {
 	if v := os.Getenv(key); v != "" {
@@ -0,0 +1,143 @@
+package jwt
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+)
+
+// jwk is one entry of a JSON Web Key Set (RFC 7517). Only the members of
+// RSA ("RSA") and symmetric ("oct") keys are read.
+type jwk struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+	K         string `json:"k"`
+}
+
+// ParseJWKS parses a JSON Web Key Set. Keys that cannot sign HS256 or RS256
+// tokens (EC keys, encryption keys) are skipped, as a shared JWKS may
+// contain keys meant for other services.
+func ParseJWKS(data []byte) (KeySet, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid JWKS: %w", err)
+	}
+
+	keys := make(KeySet, 0, len(set.Keys))
+	for i, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		switch {
+		case k.KeyType == "RSA" && (k.Algorithm == "" || k.Algorithm == RS256):
+			public, err := k.rsaPublicKey()
+			if err != nil {
+				return nil, fmt.Errorf("keys[%d]: %w", i, err)
+			}
+			keys = append(keys, RSAPublicKey(k.KeyID, public))
+		case k.KeyType == "oct" && (k.Algorithm == "" || k.Algorithm == HS256):
+			secret, err := base64.RawURLEncoding.DecodeString(k.K)
+			if err != nil || len(secret) == 0 {
+				return nil, fmt.Errorf("keys[%d]: invalid k", i)
+			}
+			keys = append(keys, HMACKey(k.KeyID, secret))
+		}
+	}
+	return keys, nil
+}
+
+func (k jwk) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil || len(n) == 0 {
+		return nil, errors.New("invalid n")
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil || len(e) == 0 || len(e) > 4 {
+		return nil, errors.New("invalid e")
+	}
+	exponent := new(big.Int).SetBytes(e).Int64()
+	if exponent < 3 {
+		return nil, errors.New("invalid e")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent)}, nil
+}
+
+// JWKSFile is a key set read from a local JWKS file. The file is re-read
+// when it changes, so keys can be rotated by publishing the new key next
+// to the old one and removing the old key once its tokens have expired.
+type JWKSFile struct {
+	path    string
+	refresh time.Duration
+
+	mu        sync.Mutex
+	keys      KeySet
+	modTime   time.Time
+	checkedAt time.Time
+}
+
+// LoadJWKSFile reads the key set at path. Afterwards the file is checked
+// for changes at most once per refresh interval, and whenever a token names
+// a key the set does not have yet.
+func LoadJWKSFile(path string, refresh time.Duration) (*JWKSFile, error) {
+	f := &JWKSFile{path: path, refresh: refresh}
+	if err := f.reload(time.Now()); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Lookup returns the key with the given ID and algorithm.
+func (f *JWKSFile) Lookup(keyID, algorithm string) (Key, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(f.checkedAt) >= f.refresh {
+		// A broken file keeps the previous keys rather than locking everyone out
+		_ = f.reload(now)
+	}
+	if key, ok := f.keys.Lookup(keyID, algorithm); ok {
+		return key, true
+	}
+	// The key may have been published since the last check
+	if !f.checkedAt.Equal(now) {
+		_ = f.reload(now)
+	}
+	return f.keys.Lookup(keyID, algorithm)
+}
+
+// reload re-reads the file if its modification time changed. The caller
+// holds f.mu, except during LoadJWKSFile.
+func (f *JWKSFile) reload(now time.Time) error {
+	f.checkedAt = now
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return err
+	}
+	if f.keys != nil && info.ModTime().Equal(f.modTime) {
+		return nil
+	}
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return err
+	}
+	keys, err := ParseJWKS(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", f.path, err)
+	}
+	f.keys = keys
+	f.modTime = info.ModTime()
+	return nil
+}
@@ -0,0 +1,155 @@
+// Package jwt signs and verifies JSON Web Tokens (RFC 7519) in compact
+// serialization, using HS256 or RS256.
+package jwt
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// Supported signing algorithms.
+const (
+	HS256 = "HS256" // HMAC with SHA-256, shared secret
+	RS256 = "RS256" // RSASSA-PKCS1-v1_5 with SHA-256, RSA key pair
+)
+
+// Claims are the registered claims the platform uses, plus the user's role.
+// Times are Unix seconds; zero values are left out of the token.
+type Claims struct {
+	Subject   string   `json:"sub,omitempty"` // User ID
+	Role      string   `json:"role,omitempty"`
+	Issuer    string   `json:"iss,omitempty"`
+	Audience  Audience `json:"aud,omitempty"`
+	IssuedAt  int64    `json:"iat,omitempty"`
+	NotBefore int64    `json:"nbf,omitempty"`
+	ExpiresAt int64    `json:"exp,omitempty"`
+	ID        string   `json:"jti,omitempty"`
+}
+
+// Audience is the "aud" claim. A single audience is written as a string,
+// as most issuers do; both forms are read.
+type Audience []string
+
+func (a Audience) MarshalJSON() ([]byte, error) {
+	if len(a) == 1 {
+		return json.Marshal(a[0])
+	}
+	return json.Marshal([]string(a))
+}
+
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return fmt.Errorf("aud must be a string or an array of strings")
+	}
+	*a = many
+	return nil
+}
+
+// Contains reports whether audience is one of a.
+func (a Audience) Contains(audience string) bool {
+	for _, aud := range a {
+		if aud == audience {
+			return true
+		}
+	}
+	return false
+}
+
+// header is the JOSE header of a token.
+type header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ,omitempty"`
+	KeyID     string `json:"kid,omitempty"`
+}
+
+// Signer issues signed tokens.
+type Signer interface {
+	Sign(claims Claims) (string, error)
+	// Key returns the key the signer's tokens are verified with.
+	Key() Key
+}
+
+// HMACSigner signs tokens with HS256.
+type HMACSigner struct {
+	keyID  string
+	secret []byte
+}
+
+// NewHS256Signer creates a signer using secret. keyID, when set, is written
+// as the token's "kid" so verifiers can pick the right key during rotation.
+func NewHS256Signer(keyID string, secret []byte) *HMACSigner {
+	return &HMACSigner{keyID: keyID, secret: secret}
+}
+
+// Sign encodes and signs claims.
+func (s *HMACSigner) Sign(claims Claims) (string, error) {
+	return encode(header{Algorithm: HS256, Type: "JWT", KeyID: s.keyID}, claims, func(signingInput []byte) ([]byte, error) {
+		return hmacSHA256(s.secret, signingInput), nil
+	})
+}
+
+// Key returns the key tokens from s are verified with.
+func (s *HMACSigner) Key() Key {
+	return HMACKey(s.keyID, s.secret)
+}
+
+// RSASigner signs tokens with RS256.
+type RSASigner struct {
+	keyID string
+	key   *rsa.PrivateKey
+}
+
+// NewRS256Signer creates a signer using key. keyID, when set, is written as
+// the token's "kid" and should match the key's entry in the JWKS.
+func NewRS256Signer(keyID string, key *rsa.PrivateKey) *RSASigner {
+	return &RSASigner{keyID: keyID, key: key}
+}
+
+// Sign encodes and signs claims.
+func (s *RSASigner) Sign(claims Claims) (string, error) {
+	return encode(header{Algorithm: RS256, Type: "JWT", KeyID: s.keyID}, claims, func(signingInput []byte) ([]byte, error) {
+		digest := sha256.Sum256(signingInput)
+		return rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	})
+}
+
+// Key returns the key tokens from s are verified with.
+func (s *RSASigner) Key() Key {
+	return RSAPublicKey(s.keyID, &s.key.PublicKey)
+}
+
+func hmacSHA256(secret, data []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// encode builds "<header>.<claims>.<signature>", each part base64url without padding.
+func encode(h header, claims Claims, sign func(signingInput []byte) ([]byte, error)) (string, error) {
+	headerJSON, err := json.Marshal(h)
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+	signature, err := sign([]byte(signingInput))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,86 @@
+package jwt
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// Key is a verification key: an HMAC secret for HS256 or an RSA public key
+// for RS256. A key only verifies tokens of its own algorithm, so an RSA
+// public key can never be used as an HMAC secret.
+type Key struct {
+	ID        string // Matched against the token's "kid"; empty matches tokens without one
+	Algorithm string
+	secret    []byte
+	public    *rsa.PublicKey
+}
+
+// HMACKey creates an HS256 key.
+func HMACKey(id string, secret []byte) Key {
+	return Key{ID: id, Algorithm: HS256, secret: secret}
+}
+
+// RSAPublicKey creates an RS256 key.
+func RSAPublicKey(id string, public *rsa.PublicKey) Key {
+	return Key{ID: id, Algorithm: RS256, public: public}
+}
+
+// KeySource finds the key for a token's "kid" and "alg".
+type KeySource interface {
+	Lookup(keyID, algorithm string) (Key, bool)
+}
+
+// KeySet is a fixed list of keys.
+type KeySet []Key
+
+// Lookup returns the key with the given ID and algorithm.
+func (s KeySet) Lookup(keyID, algorithm string) (Key, bool) {
+	for _, key := range s {
+		if key.ID == keyID && key.Algorithm == algorithm {
+			return key, true
+		}
+	}
+	return Key{}, false
+}
+
+// KeySources looks keys up in each source in turn, e.g. the configured
+// signing key first and then a JWKS file.
+type KeySources []KeySource
+
+// Lookup returns the first matching key.
+func (s KeySources) Lookup(keyID, algorithm string) (Key, bool) {
+	for _, source := range s {
+		if key, ok := source.Lookup(keyID, algorithm); ok {
+			return key, true
+		}
+	}
+	return Key{}, false
+}
+
+// ParseRSAPrivateKeyPEM parses a PKCS #1 ("RSA PRIVATE KEY") or PKCS #8
+// ("PRIVATE KEY") PEM block.
+func ParseRSAPrivateKeyPEM(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("private key is %T, not RSA", key)
+		}
+		return rsaKey, nil
+	default:
+		return nil, fmt.Errorf("unexpected PEM block %q", block.Type)
+	}
+}
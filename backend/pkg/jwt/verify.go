@@ -0,0 +1,132 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Errors returned by Verifier.Verify.
+var (
+	ErrMalformed            = errors.New("malformed token")
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+	ErrUnknownKey           = errors.New("unknown signing key")
+	ErrInvalidSignature     = errors.New("invalid signature")
+	ErrMissingExpiry        = errors.New("token has no expiry")
+	ErrExpired              = errors.New("token has expired")
+	ErrNotYetValid          = errors.New("token is not valid yet")
+	ErrInvalidIssuer        = errors.New("unexpected issuer")
+	ErrInvalidAudience      = errors.New("unexpected audience")
+)
+
+// VerifierConfig configures a Verifier.
+type VerifierConfig struct {
+	Keys KeySource
+	// Issuer and Audience, when set, must match the token's "iss" and be
+	// one of its "aud".
+	Issuer   string
+	Audience string
+	// Leeway tolerates clock skew between the issuer and this server when
+	// checking "exp" and "nbf".
+	Leeway time.Duration
+}
+
+// Verifier checks the signature and registered claims of tokens.
+type Verifier struct {
+	keys     KeySource
+	issuer   string
+	audience string
+	leeway   time.Duration
+	now      func() time.Time
+}
+
+// NewVerifier creates a verifier.
+func NewVerifier(cfg VerifierConfig) *Verifier {
+	return &Verifier{
+		keys:     cfg.Keys,
+		issuer:   cfg.Issuer,
+		audience: cfg.Audience,
+		leeway:   cfg.Leeway,
+		now:      time.Now,
+	}
+}
+
+// Verify parses token and returns its claims if the signature is valid for
+// the key named by "kid" and the token is unexpired and meant for this API.
+// Tokens without "exp" are rejected, as they would be valid forever.
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformed
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, ErrMalformed
+	}
+	if h.Algorithm != HS256 && h.Algorithm != RS256 {
+		return nil, ErrUnsupportedAlgorithm
+	}
+	key, ok := v.keys.Lookup(h.KeyID, h.Algorithm)
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	if !key.verify([]byte(parts[0]+"."+parts[1]), signature) {
+		return nil, ErrInvalidSignature
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, ErrMalformed
+	}
+	now := v.now().Unix()
+	leeway := int64(v.leeway / time.Second)
+	if claims.ExpiresAt == 0 {
+		return nil, ErrMissingExpiry
+	}
+	if now > claims.ExpiresAt+leeway {
+		return nil, ErrExpired
+	}
+	if claims.NotBefore != 0 && now+leeway < claims.NotBefore {
+		return nil, ErrNotYetValid
+	}
+	if v.issuer != "" && claims.Issuer != v.issuer {
+		return nil, ErrInvalidIssuer
+	}
+	if v.audience != "" && !claims.Audience.Contains(v.audience) {
+		return nil, ErrInvalidAudience
+	}
+	return &claims, nil
+}
+
+// verify checks signature over signingInput with the key's algorithm.
+func (k Key) verify(signingInput, signature []byte) bool {
+	switch k.Algorithm {
+	case HS256:
+		return hmac.Equal(signature, hmacSHA256(k.secret, signingInput))
+	case RS256:
+		digest := sha256.Sum256(signingInput)
+		return rsa.VerifyPKCS1v15(k.public, crypto.SHA256, digest[:], signature) == nil
+	default:
+		return false
+	}
+}
+
+// decodeSegment decodes one base64url JSON part of a token into v.
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,256 @@
+package jwt
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testNow = time.Unix(1_700_000_000, 0)
+
+func testRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func validClaims() Claims {
+	return Claims{
+		Subject:   "user-1",
+		Role:      "user",
+		Issuer:    "foodie",
+		Audience:  Audience{"foodie-api"},
+		IssuedAt:  testNow.Unix(),
+		ExpiresAt: testNow.Add(time.Hour).Unix(),
+	}
+}
+
+func newTestVerifier(keys KeySource) *Verifier {
+	v := NewVerifier(VerifierConfig{Keys: keys, Issuer: "foodie", Audience: "foodie-api", Leeway: 30 * time.Second})
+	v.now = func() time.Time { return testNow }
+	return v
+}
+
+// forge builds a token with an arbitrary header, signed by sign, or with an
+// empty signature when sign is nil.
+func forge(t *testing.T, h header, claims Claims, sign func([]byte) []byte) string {
+	t.Helper()
+	token, err := encode(h, claims, func(signingInput []byte) ([]byte, error) {
+		if sign == nil {
+			return nil, nil
+		}
+		return sign(signingInput), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestVerify(t *testing.T) {
+	rsaKey := testRSAKey(t)
+	rsaSigner := NewRS256Signer("rsa-1", rsaKey)
+	hmacSigner := NewHS256Signer("hmac-1", []byte("s3cret-s3cret-s3cret-s3cret-s3cr"))
+	keys := KeySet{rsaSigner.Key(), hmacSigner.Key()}
+
+	sign := func(s Signer, mutate func(*Claims)) func(t *testing.T) string {
+		return func(t *testing.T) string {
+			claims := validClaims()
+			if mutate != nil {
+				mutate(&claims)
+			}
+			token, err := s.Sign(claims)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return token
+		}
+	}
+
+	tests := []struct {
+		name    string
+		token   func(t *testing.T) string
+		wantErr error
+	}{
+		{name: "valid RS256", token: sign(rsaSigner, nil)},
+		{name: "valid HS256", token: sign(hmacSigner, nil)},
+		{
+			name: "bad signature",
+			token: func(t *testing.T) string {
+				token := sign(rsaSigner, nil)(t)
+				parts := strings.Split(token, ".")
+				other := sign(rsaSigner, func(c *Claims) { c.Role = "admin" })(t)
+				// The claims of one token with the signature of another
+				return parts[0] + "." + strings.Split(other, ".")[1] + "." + parts[2]
+			},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name: "signature from another secret",
+			token: func(t *testing.T) string {
+				return sign(NewHS256Signer("hmac-1", []byte("not-the-secret")), nil)(t)
+			},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name: "alg none",
+			token: func(t *testing.T) string {
+				return forge(t, header{Algorithm: "none", KeyID: "rsa-1"}, validClaims(), nil)
+			},
+			wantErr: ErrUnsupportedAlgorithm,
+		},
+		{
+			name: "HS256 signed with the RSA public key",
+			token: func(t *testing.T) string {
+				// Algorithm confusion: the public key is no secret, so it must
+				// never be accepted as an HMAC key
+				public := rsaKey.PublicKey.N.Bytes()
+				return forge(t, header{Algorithm: HS256, KeyID: "rsa-1"}, validClaims(), func(in []byte) []byte {
+					return hmacSHA256(public, in)
+				})
+			},
+			wantErr: ErrUnknownKey,
+		},
+		{
+			name: "unknown kid",
+			token: func(t *testing.T) string {
+				return sign(NewHS256Signer("hmac-2", []byte("s3cret-s3cret-s3cret-s3cret-s3cr")), nil)(t)
+			},
+			wantErr: ErrUnknownKey,
+		},
+		{
+			name:    "malformed",
+			token:   func(t *testing.T) string { return "not.a-token" },
+			wantErr: ErrMalformed,
+		},
+		{
+			name:    "missing exp",
+			token:   sign(rsaSigner, func(c *Claims) { c.ExpiresAt = 0 }),
+			wantErr: ErrMissingExpiry,
+		},
+		{
+			name:    "expired",
+			token:   sign(rsaSigner, func(c *Claims) { c.ExpiresAt = testNow.Add(-time.Minute).Unix() }),
+			wantErr: ErrExpired,
+		},
+		{
+			name:  "expired within leeway",
+			token: sign(rsaSigner, func(c *Claims) { c.ExpiresAt = testNow.Add(-10 * time.Second).Unix() }),
+		},
+		{
+			name:    "nbf in the future",
+			token:   sign(rsaSigner, func(c *Claims) { c.NotBefore = testNow.Add(time.Minute).Unix() }),
+			wantErr: ErrNotYetValid,
+		},
+		{
+			name:  "nbf within leeway",
+			token: sign(rsaSigner, func(c *Claims) { c.NotBefore = testNow.Add(10 * time.Second).Unix() }),
+		},
+		{
+			name:    "wrong issuer",
+			token:   sign(rsaSigner, func(c *Claims) { c.Issuer = "someone-else" }),
+			wantErr: ErrInvalidIssuer,
+		},
+		{
+			name:    "wrong audience",
+			token:   sign(rsaSigner, func(c *Claims) { c.Audience = Audience{"other-api"} }),
+			wantErr: ErrInvalidAudience,
+		},
+		{
+			name:  "audience among several",
+			token: sign(rsaSigner, func(c *Claims) { c.Audience = Audience{"other-api", "foodie-api"} }),
+		},
+	}
+
+	verifier := newTestVerifier(keys)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := verifier.Verify(tt.token(t))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Verify error %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if claims.Subject != "user-1" {
+				t.Fatalf("subject %q, want user-1", claims.Subject)
+			}
+		})
+	}
+}
+
+func TestVerifyReloadsJWKSForUnknownKeyID(t *testing.T) {
+	oldKey, newKey := testRSAKey(t), testRSAKey(t)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, map[string]*rsa.PrivateKey{"old": oldKey}, testNow)
+
+	// A long refresh interval, so only the unknown kid can trigger the reload
+	file, err := LoadJWKSFile(path, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier := newTestVerifier(file)
+
+	newToken, err := NewRS256Signer("new", newKey).Sign(validClaims())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := verifier.Verify(newToken); !errors.Is(err, ErrUnknownKey) {
+		t.Fatalf("Verify before rotation: %v, want %v", err, ErrUnknownKey)
+	}
+
+	writeJWKS(t, path, map[string]*rsa.PrivateKey{"old": oldKey, "new": newKey}, testNow.Add(time.Minute))
+	if _, err := verifier.Verify(newToken); err != nil {
+		t.Fatalf("Verify after rotation: %v", err)
+	}
+	oldToken, err := NewRS256Signer("old", oldKey).Sign(validClaims())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := verifier.Verify(oldToken); err != nil {
+		t.Fatalf("Verify with the old key still published: %v", err)
+	}
+}
+
+// writeJWKS publishes the public halves of keys at path with the given
+// modification time, which is what JWKSFile watches.
+func writeJWKS(t *testing.T, path string, keys map[string]*rsa.PrivateKey, modTime time.Time) {
+	t.Helper()
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	for id, key := range keys {
+		set.Keys = append(set.Keys, jwk{
+			KeyType:   "RSA",
+			KeyID:     id,
+			Use:       "sig",
+			Algorithm: RS256,
+			N:         base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()),
+			E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.PublicKey.E)).Bytes()),
+		})
+	}
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
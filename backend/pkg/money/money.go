@@ -0,0 +1,298 @@
+// Package money provides an exact monetary amount type.
+//
+// Amounts are held as integer minor units (cents for USD, whole dong for VND)
+// together with an ISO 4217 currency code, so sums never drift the way
+// float64 prices do. Rounding, where it is unavoidable (percentages, parsing
+// more decimals than the currency has), is half away from zero.
+package money
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is used when no currency is configured.
+const DefaultCurrency = "USD"
+
+// zeroDecimalCurrencies have no minor unit. Every other currency uses two
+// decimal places, matching the DECIMAL(10, 2) columns that store amounts.
+var zeroDecimalCurrencies = map[string]bool{
+	"CLP": true, "ISK": true, "JPY": true, "KRW": true, "VND": true,
+}
+
+// Money is an amount in minor units of Currency.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// New creates Money from an amount in minor units.
+func New(minor int64, currency string) Money {
+	return Money{Amount: minor, Currency: currency}
+}
+
+// Zero returns a zero amount in currency.
+func Zero(currency string) Money {
+	return Money{Currency: currency}
+}
+
+// FromFloat converts a major-unit float (e.g. 12.34) to Money, rounding to the
+// currency's minor unit. Use it only at boundaries such as configuration.
+func FromFloat(major float64, currency string) Money {
+	return Money{Amount: int64(math.Round(major * float64(scale(currency)))), Currency: currency}
+}
+
+// Parse converts a decimal string such as "12.34" or "-0.5" to Money.
+// Digits beyond the currency's minor unit are rounded half away from zero.
+func Parse(decimal, currency string) (Money, error) {
+	s := strings.TrimSpace(decimal)
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+
+	whole, frac, _ := strings.Cut(s, ".")
+	// "", "-" and "." have no digits at all and must not read as zero
+	if whole+frac == "" || !isDigits(whole) || !isDigits(frac) || len(whole) > 15 {
+		return Money{}, fmt.Errorf("invalid amount %q", decimal)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+
+	digits := Exponent(currency)
+	roundUp := len(frac) > digits && frac[digits] >= '5'
+	if len(frac) > digits {
+		frac = frac[:digits]
+	}
+	frac += strings.Repeat("0", digits-len(frac))
+
+	amount, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid amount %q: %w", decimal, err)
+	}
+	if roundUp {
+		amount++
+	}
+	if negative {
+		amount = -amount
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// MustParse is like Parse but panics on error. Intended for constants.
+func MustParse(decimal, currency string) Money {
+	m, err := Parse(decimal, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// ValidCurrency reports whether code looks like an ISO 4217 code (three upper-case letters).
+func ValidCurrency(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Exponent returns the number of decimal places of currency's minor unit.
+func Exponent(currency string) int {
+	if zeroDecimalCurrencies[currency] {
+		return 0
+	}
+	return 2
+}
+
+func scale(currency string) int64 {
+	if Exponent(currency) == 0 {
+		return 1
+	}
+	return 100
+}
+
+// WithCurrency returns m in currency if it has none yet. Amounts without a
+// currency are held with two decimals, so they are re-scaled to currency's
+// minor unit: 15000 read from legacy JSON stays 15000 VND, not 1500000.
+// Amounts that already have a currency are returned unchanged.
+func (m Money) WithCurrency(currency string) Money {
+	if m.Currency != "" {
+		return m
+	}
+	amount := m.Amount
+	if from, to := scale(""), scale(currency); from != to {
+		amount = int64(math.Round(float64(amount) * float64(to) / float64(from)))
+	}
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Add returns m + o. It panics if the currencies differ.
+func (m Money) Add(o Money) Money {
+	m.mustMatch(o)
+	return Money{Amount: m.Amount + o.Amount, Currency: m.currency(o)}
+}
+
+// Sub returns m - o. It panics if the currencies differ.
+func (m Money) Sub(o Money) Money {
+	m.mustMatch(o)
+	return Money{Amount: m.Amount - o.Amount, Currency: m.currency(o)}
+}
+
+// Mul returns m multiplied by a whole quantity.
+func (m Money) Mul(quantity int) Money {
+	return Money{Amount: m.Amount * int64(quantity), Currency: m.Currency}
+}
+
+// Percent returns percent% of m, rounded half away from zero to the minor unit.
+func (m Money) Percent(percent float64) Money {
+	return Money{Amount: int64(math.Round(float64(m.Amount) * percent / 100)), Currency: m.Currency}
+}
+
+// Min returns the smaller of m and o. It panics if the currencies differ.
+func (m Money) Min(o Money) Money {
+	m.mustMatch(o)
+	if o.Amount < m.Amount {
+		return Money{Amount: o.Amount, Currency: m.currency(o)}
+	}
+	return Money{Amount: m.Amount, Currency: m.currency(o)}
+}
+
+// Neg returns -m.
+func (m Money) Neg() Money {
+	return Money{Amount: -m.Amount, Currency: m.Currency}
+}
+
+// IsZero reports whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// IsPositive reports whether the amount is greater than zero.
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+// IsNegative reports whether the amount is less than zero.
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// Cmp compares m and o, returning -1, 0 or +1. It panics if the currencies differ.
+func (m Money) Cmp(o Money) int {
+	m.mustMatch(o)
+	switch {
+	case m.Amount < o.Amount:
+		return -1
+	case m.Amount > o.Amount:
+		return 1
+	}
+	return 0
+}
+
+// Decimal formats the amount in major units, e.g. "12.34" or "15000".
+func (m Money) Decimal() string {
+	digits := Exponent(m.Currency)
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	if digits == 0 {
+		return sign + strconv.FormatInt(amount, 10)
+	}
+	s := scale(m.Currency)
+	return fmt.Sprintf("%s%d.%0*d", sign, amount/s, digits, amount%s)
+}
+
+// Float returns the amount in major units as a float64, for adapters whose APIs
+// only accept floats. Never do arithmetic on the result.
+func (m Money) Float() float64 {
+	return float64(m.Amount) / float64(scale(m.Currency))
+}
+
+// String formats m as "12.34 USD".
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+// jsonMoney is the wire format: the amount as a decimal string to avoid float rounding.
+type jsonMoney struct {
+	Amount   json.RawMessage `json:"amount"`
+	Currency string          `json:"currency"`
+}
+
+// MarshalJSON encodes m as {"amount":"12.34","currency":"USD"}.
+func (m Money) MarshalJSON() ([]byte, error) {
+	amount, _ := json.Marshal(m.Decimal())
+	return json.Marshal(jsonMoney{Amount: amount, Currency: m.Currency})
+}
+
+// UnmarshalJSON decodes {"amount":"12.34","currency":"USD"}. A JSON number is
+// accepted in place of the amount string, and a bare number in place of the
+// object, so values written before Money existed can still be read; their
+// currency is left empty for the caller to fill in with WithCurrency. JSON
+// null leaves m unchanged, as it does for other types.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var obj jsonMoney
+	raw := data
+	if len(data) > 0 && data[0] == '{' {
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+		raw = obj.Amount
+		if len(raw) == 0 || string(raw) == "null" {
+			return fmt.Errorf("money amount is required")
+		}
+	}
+
+	var decimal string
+	if len(raw) > 0 && raw[0] == '"' {
+		if err := json.Unmarshal(raw, &decimal); err != nil {
+			return err
+		}
+	} else {
+		decimal = string(raw)
+	}
+
+	parsed, err := Parse(decimal, obj.Currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// mustMatch panics when combining amounts in different currencies.
+// An empty currency matches anything so Zero("") can seed a sum.
+func (m Money) mustMatch(o Money) {
+	if m.Currency != "" && o.Currency != "" && m.Currency != o.Currency {
+		panic(fmt.Sprintf("money: currency mismatch %s vs %s", m.Currency, o.Currency))
+	}
+}
+
+func (m Money) currency(o Money) string {
+	if m.Currency != "" {
+		return m.Currency
+	}
+	return o.Currency
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,251 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		decimal  string
+		currency string
+		want     int64
+		wantErr  bool
+	}{
+		{decimal: "12.34", currency: "USD", want: 1234},
+		{decimal: "12", currency: "USD", want: 1200},
+		{decimal: "12.3", currency: "USD", want: 1230},
+		{decimal: ".5", currency: "USD", want: 50},
+		{decimal: "5.", currency: "USD", want: 500},
+		{decimal: "+1.00", currency: "USD", want: 100},
+		{decimal: " 7.25 ", currency: "USD", want: 725},
+		{decimal: "0", currency: "USD", want: 0},
+		// Digits beyond the minor unit round half away from zero
+		{decimal: "1.005", currency: "USD", want: 101},
+		{decimal: "1.004", currency: "USD", want: 100},
+		{decimal: "-1.005", currency: "USD", want: -101},
+		{decimal: "-1.004", currency: "USD", want: -100},
+		{decimal: "-0.5", currency: "USD", want: -50},
+		// Zero-decimal currencies
+		{decimal: "15000", currency: "VND", want: 15000},
+		{decimal: "15000.5", currency: "VND", want: 15001},
+		{decimal: "15000.49", currency: "VND", want: 15000},
+		{decimal: "-15000.5", currency: "VND", want: -15001},
+		{decimal: "1200", currency: "JPY", want: 1200},
+		// No currency yet: two decimals, as for legacy values
+		{decimal: "15000", currency: "", want: 1500000},
+		// No digits, or not a number
+		{decimal: "", wantErr: true},
+		{decimal: "-", wantErr: true},
+		{decimal: "+", wantErr: true},
+		{decimal: ".", wantErr: true},
+		{decimal: "-.", wantErr: true},
+		{decimal: "null", wantErr: true},
+		{decimal: "1e3", wantErr: true},
+		{decimal: "1.2.3", wantErr: true},
+		{decimal: "--1", wantErr: true},
+		{decimal: "1,50", wantErr: true},
+		{decimal: "1234567890123456", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.decimal, tt.currency)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Parse(%q, %q) = %v, want an error", tt.decimal, tt.currency, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q, %q): %v", tt.decimal, tt.currency, err)
+			continue
+		}
+		if got.Amount != tt.want || got.Currency != tt.currency {
+			t.Errorf("Parse(%q, %q) = %d %s, want %d %s", tt.decimal, tt.currency, got.Amount, got.Currency, tt.want, tt.currency)
+		}
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	tests := []struct {
+		m    Money
+		want string
+	}{
+		{New(1234, "USD"), "12.34"},
+		{New(5, "USD"), "0.05"},
+		{New(-5, "USD"), "-0.05"},
+		{New(-1234, "USD"), "-12.34"},
+		{New(0, "USD"), "0.00"},
+		{New(15000, "VND"), "15000"},
+		{New(-15000, "VND"), "-15000"},
+		{New(1200, "JPY"), "1200"},
+	}
+	for _, tt := range tests {
+		if got := tt.m.Decimal(); got != tt.want {
+			t.Errorf("%d %s: Decimal() = %q, want %q", tt.m.Amount, tt.m.Currency, got, tt.want)
+		}
+		back, err := Parse(tt.m.Decimal(), tt.m.Currency)
+		if err != nil || back != tt.m {
+			t.Errorf("Parse(Decimal()) of %v = %v, %v; want it unchanged", tt.m, back, err)
+		}
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	a, b := MustParse("10.50", "USD"), MustParse("3.25", "USD")
+	if got := a.Add(b); got != New(1375, "USD") {
+		t.Errorf("Add = %v, want 13.75 USD", got)
+	}
+	if got := b.Sub(a); got != New(-725, "USD") || !got.IsNegative() {
+		t.Errorf("Sub = %v, want -7.25 USD", got)
+	}
+	if got := b.Mul(3); got != New(975, "USD") {
+		t.Errorf("Mul = %v, want 9.75 USD", got)
+	}
+	if got := a.Min(b); got != b {
+		t.Errorf("Min = %v, want %v", got, b)
+	}
+	if got := a.Neg(); got != New(-1050, "USD") {
+		t.Errorf("Neg = %v, want -10.50 USD", got)
+	}
+	if a.Cmp(b) != 1 || b.Cmp(a) != -1 || a.Cmp(a) != 0 {
+		t.Errorf("Cmp ordered %v and %v wrongly", a, b)
+	}
+	// Zero("") seeds a sum in any currency
+	if got := Zero("").Add(a); got != a {
+		t.Errorf("Zero(\"\").Add = %v, want %v", got, a)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("adding USD to VND did not panic")
+		}
+	}()
+	a.Add(New(1, "VND"))
+}
+
+func TestPercentRoundsHalfAwayFromZero(t *testing.T) {
+	tests := []struct {
+		m       Money
+		percent float64
+		want    int64
+	}{
+		{New(1000, "USD"), 10, 100},
+		{New(5, "USD"), 10, 1},   // 0.5 cent rounds up
+		{New(-5, "USD"), 10, -1}, // and away from zero when negative
+		{New(4, "USD"), 10, 0},
+		{New(15, "VND"), 10, 2},
+		{New(15000, "VND"), 8, 1200},
+	}
+	for _, tt := range tests {
+		got := tt.m.Percent(tt.percent)
+		if got.Amount != tt.want || got.Currency != tt.m.Currency {
+			t.Errorf("%v.Percent(%v) = %v, want %d %s", tt.m, tt.percent, got, tt.want, tt.m.Currency)
+		}
+	}
+}
+
+func TestFromFloat(t *testing.T) {
+	if got := FromFloat(12.345, "USD"); got != New(1235, "USD") {
+		t.Errorf("FromFloat(12.345, USD) = %v, want 12.35 USD", got)
+	}
+	if got := FromFloat(15000, "VND"); got != New(15000, "VND") {
+		t.Errorf("FromFloat(15000, VND) = %v, want 15000 VND", got)
+	}
+}
+
+func TestWithCurrency(t *testing.T) {
+	tests := []struct {
+		m        Money
+		currency string
+		want     Money
+	}{
+		// Legacy amounts hold two decimals until their currency is known
+		{MustParse("15000", ""), "VND", New(15000, "VND")},
+		{MustParse("1200", ""), "JPY", New(1200, "JPY")},
+		{MustParse("12.34", ""), "USD", New(1234, "USD")},
+		{MustParse("12.5", ""), "JPY", New(13, "JPY")},
+		{MustParse("-12.5", ""), "JPY", New(-13, "JPY")},
+		// An amount with a currency is never re-labelled
+		{New(1234, "USD"), "VND", New(1234, "USD")},
+	}
+	for _, tt := range tests {
+		if got := tt.m.WithCurrency(tt.currency); got != tt.want {
+			t.Errorf("%v.WithCurrency(%s) = %v, want %v", tt.m, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    Money
+		wantErr bool
+	}{
+		{name: "object", json: `{"amount":"12.34","currency":"USD"}`, want: New(1234, "USD")},
+		{name: "object in VND", json: `{"amount":"15000","currency":"VND"}`, want: New(15000, "VND")},
+		{name: "object with negative amount", json: `{"amount":"-0.50","currency":"USD"}`, want: New(-50, "USD")},
+		{name: "object with a number amount", json: `{"amount":12.34,"currency":"USD"}`, want: New(1234, "USD")},
+		{name: "legacy bare number", json: `12.34`, want: New(1234, "")},
+		{name: "legacy bare integer", json: `15000`, want: New(1500000, "")},
+		{name: "empty amount", json: `{"amount":"","currency":"USD"}`, wantErr: true},
+		{name: "sign only", json: `{"amount":"-","currency":"USD"}`, wantErr: true},
+		{name: "null amount", json: `{"amount":null,"currency":"USD"}`, wantErr: true},
+		{name: "missing amount", json: `{"currency":"USD"}`, wantErr: true},
+		{name: "not a number", json: `{"amount":"abc","currency":"USD"}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Money
+			err := json.Unmarshal([]byte(tt.json), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("decoded %v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("decoded %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("legacy VND price keeps its value", func(t *testing.T) {
+		var got Money
+		if err := json.Unmarshal([]byte(`15000`), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got = got.WithCurrency("VND"); got != New(15000, "VND") {
+			t.Fatalf("got %v, want 15000 VND", got)
+		}
+	})
+
+	t.Run("null leaves the value alone", func(t *testing.T) {
+		var holder struct {
+			Price *Money `json:"price"`
+		}
+		if err := json.Unmarshal([]byte(`{"price":null}`), &holder); err != nil || holder.Price != nil {
+			t.Fatalf("got %v, %v; want a nil price and no error", holder.Price, err)
+		}
+		m := New(100, "USD")
+		if err := m.UnmarshalJSON([]byte("null")); err != nil || m != New(100, "USD") {
+			t.Fatalf("got %v, %v; want 1.00 USD unchanged", m, err)
+		}
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		for _, m := range []Money{New(1234, "USD"), New(-5, "USD"), New(15000, "VND")} {
+			data, err := json.Marshal(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var back Money
+			if err := json.Unmarshal(data, &back); err != nil || back != m {
+				t.Fatalf("%s decoded to %v, %v; want %v", data, back, err, m)
+			}
+		}
+	})
+}
@@ -57,6 +57,25 @@ func Error(w http.ResponseWriter, status int, message string, err error) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// ErrorWithData sends an error response that also carries data, e.g. a
+// resource the failed request still created.
+func ErrorWithData(w http.ResponseWriter, status int, message string, err error, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	response := Response{
+		Success: false,
+		Data:    data,
+		Error:   message,
+	}
+
+	if err != nil {
+		response.Message = err.Error()
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
+
 // BadRequest sends a bad request response (400 Bad Request).
 func BadRequest(w http.ResponseWriter, message string, err error) {
 	Error(w, http.StatusBadRequest, message, err)
@@ -67,6 +86,11 @@ func NotFound(w http.ResponseWriter, message string) {
 	Error(w, http.StatusNotFound, message, nil)
 }
 
+// Conflict sends a conflict response (409 Conflict).
+func Conflict(w http.ResponseWriter, message string, err error) {
+	Error(w, http.StatusConflict, message, err)
+}
+
 // InternalServerError sends an internal server error response (500 Internal Server Error).
 func InternalServerError(w http.ResponseWriter, message string, err error) {
 	Error(w, http.StatusInternalServerError, message, err)
@@ -0,0 +1,100 @@
+package image
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"net/http"
+
+	// Register the decoders image.Decode understands
+	_ "image/gif"
+	_ "image/png"
+)
+
+// Supported content types and the file extension each is stored with.
+var extensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// DetectContentType sniffs the content type of data, ignoring whatever the
+// client claimed it was.
+func DetectContentType(data []byte) string {
+	return http.DetectContentType(data)
+}
+
+// Extension returns the file extension for a supported content type.
+func Extension(contentType string) (string, bool) {
+	ext, ok := extensions[contentType]
+	return ext, ok
+}
+
+// Thumbnail decodes an image and scales it down to fit in a maxSize x maxSize
+// box, keeping its aspect ratio, and encodes the result as JPEG. Images that
+// already fit are re-encoded at their own size. Transparent areas become white.
+func Thumbnail(data []byte, maxSize int) ([]byte, error) {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	bounds := src.Bounds()
+	width, height := fit(bounds.Dx(), bounds.Dy(), maxSize)
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	scale(dst, src)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85}); err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// fit returns the size of a width x height image scaled down to fit in a
+// maxSize square. It never scales up.
+func fit(width, height, maxSize int) (int, int) {
+	if width <= maxSize && height <= maxSize {
+		return width, height
+	}
+	if width >= height {
+		return maxSize, max(1, height*maxSize/width)
+	}
+	return max(1, width*maxSize/height), maxSize
+}
+
+// scale draws src over dst, averaging every source pixel that falls into
+// each destination pixel (a box filter), so thumbnails do not alias.
+func scale(dst *image.RGBA, src image.Image) {
+	sb := src.Bounds()
+	db := dst.Bounds()
+	for y := 0; y < db.Dy(); y++ {
+		y0 := sb.Min.Y + y*sb.Dy()/db.Dy()
+		y1 := max(y0+1, sb.Min.Y+(y+1)*sb.Dy()/db.Dy())
+		for x := 0; x < db.Dx(); x++ {
+			x0 := sb.Min.X + x*sb.Dx()/db.Dx()
+			x1 := max(x0+1, sb.Min.X+(x+1)*sb.Dx()/db.Dx())
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+			// Colors are alpha-premultiplied, so compositing over the
+			// white background adds white for the missing coverage
+			white := 0xffff*n - a
+			dst.SetRGBA64(x, y, color.RGBA64{
+				R: uint16((r + white) / n),
+				G: uint16((g + white) / n),
+				B: uint16((b + white) / n),
+				A: 0xffff,
+			})
+		}
+	}
+}
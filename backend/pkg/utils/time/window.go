@@ -0,0 +1,274 @@
+package time
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MinutesPerDay is the number of minutes in a day, and the largest Clock.
+const MinutesPerDay = 24 * 60
+
+// Clock is a time of day in minutes after midnight. It is written as "HH:MM";
+// "24:00" is allowed as the end of a window that runs until midnight.
+type Clock int
+
+// ParseClock parses a "HH:MM" time of day.
+func ParseClock(s string) (Clock, error) {
+	hours, minutes, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok || len(minutes) != 2 {
+		return 0, fmt.Errorf("invalid time of day %q: expected HH:MM", s)
+	}
+	h, err := strconv.Atoi(hours)
+	if err != nil || h < 0 || h > 24 {
+		return 0, fmt.Errorf("invalid time of day %q: expected HH:MM", s)
+	}
+	m, err := strconv.Atoi(minutes)
+	if err != nil || m < 0 || m > 59 {
+		return 0, fmt.Errorf("invalid time of day %q: expected HH:MM", s)
+	}
+	c := Clock(h*60 + m)
+	if c > MinutesPerDay {
+		return 0, fmt.Errorf("invalid time of day %q: expected HH:MM", s)
+	}
+	return c, nil
+}
+
+// ClockOf returns the time of day of t, in t's location.
+func ClockOf(t time.Time) Clock {
+	return Clock(t.Hour()*60 + t.Minute())
+}
+
+// String formats c as "HH:MM".
+func (c Clock) String() string {
+	return fmt.Sprintf("%02d:%02d", int(c)/60, int(c)%60)
+}
+
+func (c Clock) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+func (c *Clock) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("time of day must be a \"HH:MM\" string")
+	}
+	parsed, err := ParseClock(s)
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+// weekdayNames are the short weekday names used in JSON, indexed by time.Weekday.
+var weekdayNames = [...]string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// ParseWeekday parses a weekday name, full or abbreviated to three letters,
+// in any case.
+func ParseWeekday(s string) (time.Weekday, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if name == weekdayNames[day] || name == strings.ToLower(day.String()) {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid weekday %q", s)
+}
+
+// FormatWeekday returns the short lower-case name of day, e.g. "mon".
+func FormatWeekday(day time.Weekday) string {
+	return weekdayNames[day]
+}
+
+// Window is a recurring weekly period in local time, e.g. weekdays from
+// 07:00 to 11:00. A window whose end is not after its start runs past
+// midnight into the next day; the days are the days it starts on.
+type Window struct {
+	Days  []time.Weekday // Empty means every day
+	Start Clock
+	End   Clock
+}
+
+// windowJSON is the JSON form of a Window, with weekdays by name.
+type windowJSON struct {
+	Days  []string `json:"days,omitempty"`
+	Start Clock    `json:"start"`
+	End   Clock    `json:"end"`
+}
+
+func (w Window) MarshalJSON() ([]byte, error) {
+	out := windowJSON{Start: w.Start, End: w.End}
+	for _, day := range w.Days {
+		out.Days = append(out.Days, weekdayNames[day])
+	}
+	return json.Marshal(out)
+}
+
+func (w *Window) UnmarshalJSON(data []byte) error {
+	var in windowJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	window := Window{Start: in.Start, End: in.End}
+	for _, name := range in.Days {
+		day, err := ParseWeekday(name)
+		if err != nil {
+			return err
+		}
+		window.Days = append(window.Days, day)
+	}
+	*w = window
+	return nil
+}
+
+// Validate checks that the window's times are in range and not equal.
+func (w Window) Validate() error {
+	if w.Start < 0 || w.Start >= MinutesPerDay {
+		return fmt.Errorf("window start %s must be before 24:00", w.Start)
+	}
+	if w.End < 0 || w.End > MinutesPerDay {
+		return fmt.Errorf("window end %s must be at most 24:00", w.End)
+	}
+	if w.Start == w.End {
+		return fmt.Errorf("window %s-%s is empty; use 00:00-24:00 for a whole day", w.Start, w.End)
+	}
+	for _, day := range w.Days {
+		if day < time.Sunday || day > time.Saturday {
+			return fmt.Errorf("invalid weekday %d", day)
+		}
+	}
+	return nil
+}
+
+// String formats the window as e.g. "mon,tue 07:00-11:00".
+func (w Window) String() string {
+	span := w.Start.String() + "-" + w.End.String()
+	if len(w.Days) == 0 {
+		return span
+	}
+	names := make([]string, len(w.Days))
+	for i, day := range w.Days {
+		names[i] = weekdayNames[day]
+	}
+	return strings.Join(names, ",") + " " + span
+}
+
+// Contains reports whether t falls inside the window, reading t in loc.
+func (w Window) Contains(t time.Time, loc *time.Location) bool {
+	return w.containsMoment(MomentIn(t, loc))
+}
+
+func (w Window) containsMoment(m Moment) bool {
+	for _, slot := range w.Slots() {
+		if slot.Contains(m) {
+			return true
+		}
+	}
+	return false
+}
+
+// onDay reports whether the window starts on day.
+func (w Window) onDay(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, d := range w.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// Slots expands the window into same-day ranges, one per day it covers,
+// splitting windows that run past midnight in two. Slots are what a
+// database can match a Moment against without knowing about overnight windows.
+func (w Window) Slots() []Slot {
+	var slots []Slot
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if !w.onDay(day) {
+			continue
+		}
+		if w.Start < w.End {
+			slots = append(slots, Slot{Day: day, Start: w.Start, End: w.End})
+			continue
+		}
+		slots = append(slots, Slot{Day: day, Start: w.Start, End: MinutesPerDay})
+		if w.End > 0 {
+			slots = append(slots, Slot{Day: (day + 1) % 7, Start: 0, End: w.End})
+		}
+	}
+	return slots
+}
+
+// Slot is a time range within a single weekday; it contains Start but not End.
+type Slot struct {
+	Day   time.Weekday
+	Start Clock
+	End   Clock
+}
+
+// Contains reports whether m falls inside the slot.
+func (s Slot) Contains(m Moment) bool {
+	return m.Day == s.Day && s.Start <= m.Clock && m.Clock < s.End
+}
+
+// MarshalJSON writes times as plain minutes, so databases can compare them.
+func (s Slot) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Day   int `json:"day"`
+		Start int `json:"start_minute"`
+		End   int `json:"end_minute"`
+	}{int(s.Day), int(s.Start), int(s.End)})
+}
+
+// Moment is a point in the week: a weekday and a time of day.
+type Moment struct {
+	Day   time.Weekday
+	Clock Clock
+}
+
+// MomentIn returns the weekday and time of day of t in loc.
+func MomentIn(t time.Time, loc *time.Location) Moment {
+	if loc != nil {
+		t = t.In(loc)
+	}
+	return Moment{Day: t.Weekday(), Clock: ClockOf(t)}
+}
+
+// InAnyWindow reports whether t, read in loc, falls inside any of windows.
+// No windows at all means no restriction, so it reports true.
+func InAnyWindow(windows []Window, t time.Time, loc *time.Location) bool {
+	if len(windows) == 0 {
+		return true
+	}
+	m := MomentIn(t, loc)
+	for _, w := range windows {
+		if w.containsMoment(m) {
+			return true
+		}
+	}
+	return false
+}
+
+// SlotsOf expands every window into slots; see Window.Slots.
+func SlotsOf(windows []Window) []Slot {
+	slots := make([]Slot, 0, len(windows))
+	for _, w := range windows {
+		slots = append(slots, w.Slots()...)
+	}
+	return slots
+}
+
+// ValidateWindows validates each window, naming the offending one by position.
+func ValidateWindows(windows []Window) error {
+	for i, w := range windows {
+		if err := w.Validate(); err != nil {
+			return fmt.Errorf("availability[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package time
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func clock(t *testing.T, s string) Clock {
+	t.Helper()
+	c, err := ParseClock(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Clock
+		wantErr bool
+	}{
+		{in: "00:00", want: 0},
+		{in: "07:05", want: 7*60 + 5},
+		{in: "7:05", want: 7*60 + 5},
+		{in: "23:59", want: MinutesPerDay - 1},
+		{in: "24:00", want: MinutesPerDay},
+		{in: " 12:30 ", want: 12*60 + 30},
+		{in: "24:01", wantErr: true},
+		{in: "25:00", wantErr: true},
+		{in: "7:5", wantErr: true},
+		{in: "07:60", wantErr: true},
+		{in: "-1:00", wantErr: true},
+		{in: "0700", wantErr: true},
+		{in: "", wantErr: true},
+		{in: "ab:cd", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseClock(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseClock(%q) = %s, want an error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseClock(%q) = %d, %v; want %d", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestWindowSlots(t *testing.T) {
+	tests := []struct {
+		name   string
+		window Window
+		want   []Slot
+	}{
+		{
+			name:   "same day",
+			window: Window{Days: []time.Weekday{time.Monday}, Start: clock(t, "07:00"), End: clock(t, "11:00")},
+			want:   []Slot{{Day: time.Monday, Start: clock(t, "07:00"), End: clock(t, "11:00")}},
+		},
+		{
+			name:   "crossing midnight",
+			window: Window{Days: []time.Weekday{time.Friday}, Start: clock(t, "22:00"), End: clock(t, "02:00")},
+			want: []Slot{
+				{Day: time.Friday, Start: clock(t, "22:00"), End: MinutesPerDay},
+				{Day: time.Saturday, Start: 0, End: clock(t, "02:00")},
+			},
+		},
+		{
+			name:   "saturday rolls into sunday",
+			window: Window{Days: []time.Weekday{time.Saturday}, Start: clock(t, "22:00"), End: clock(t, "02:00")},
+			want: []Slot{
+				{Day: time.Sunday, Start: 0, End: clock(t, "02:00")},
+				{Day: time.Saturday, Start: clock(t, "22:00"), End: MinutesPerDay},
+			},
+		},
+		{
+			name:   "ending at 00:00 stays on its day",
+			window: Window{Days: []time.Weekday{time.Monday}, Start: clock(t, "22:00"), End: clock(t, "00:00")},
+			want:   []Slot{{Day: time.Monday, Start: clock(t, "22:00"), End: MinutesPerDay}},
+		},
+		{
+			name:   "ending at 24:00",
+			window: Window{Days: []time.Weekday{time.Monday}, Start: clock(t, "22:00"), End: clock(t, "24:00")},
+			want:   []Slot{{Day: time.Monday, Start: clock(t, "22:00"), End: MinutesPerDay}},
+		},
+		{
+			name:   "whole day",
+			window: Window{Days: []time.Weekday{time.Sunday}, Start: clock(t, "00:00"), End: clock(t, "24:00")},
+			want:   []Slot{{Day: time.Sunday, Start: 0, End: MinutesPerDay}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.window.Slots()
+			// Slots are ordered by weekday, so a Saturday window lists Sunday first
+			if len(got) != len(tt.want) {
+				t.Fatalf("Slots() = %v, want %v", got, tt.want)
+			}
+			for _, want := range tt.want {
+				found := false
+				for _, slot := range got {
+					if reflect.DeepEqual(slot, want) {
+						found = true
+					}
+				}
+				if !found {
+					t.Fatalf("Slots() = %v, missing %v", got, want)
+				}
+			}
+		})
+	}
+
+	t.Run("every day", func(t *testing.T) {
+		got := Window{Start: clock(t, "22:00"), End: clock(t, "02:00")}.Slots()
+		if len(got) != 14 {
+			t.Fatalf("Slots() returned %d slots, want 14", len(got))
+		}
+	})
+}
+
+func TestInAnyWindow(t *testing.T) {
+	// 2024-01-06 is a Saturday
+	at := func(day int, hhmm string) time.Time {
+		c := clock(t, hhmm)
+		return time.Date(2024, 1, day, int(c)/60, int(c)%60, 0, 0, time.UTC)
+	}
+	lateNight := Window{Days: []time.Weekday{time.Saturday}, Start: clock(t, "22:00"), End: clock(t, "02:00")}
+	untilMidnight := Window{Days: []time.Weekday{time.Saturday}, Start: clock(t, "22:00"), End: clock(t, "00:00")}
+	wholeDay := Window{Days: []time.Weekday{time.Saturday}, Start: clock(t, "00:00"), End: clock(t, "24:00")}
+
+	tests := []struct {
+		name    string
+		windows []Window
+		t       time.Time
+		want    bool
+	}{
+		{name: "no windows", windows: nil, t: at(6, "03:00"), want: true},
+		{name: "saturday night", windows: []Window{lateNight}, t: at(6, "23:30"), want: true},
+		{name: "at the start", windows: []Window{lateNight}, t: at(6, "22:00"), want: true},
+		{name: "before the start", windows: []Window{lateNight}, t: at(6, "21:59"), want: false},
+		{name: "sunday after midnight", windows: []Window{lateNight}, t: at(7, "00:00"), want: true},
+		{name: "sunday before the end", windows: []Window{lateNight}, t: at(7, "01:59"), want: true},
+		{name: "at the end", windows: []Window{lateNight}, t: at(7, "02:00"), want: false},
+		{name: "friday after midnight", windows: []Window{lateNight}, t: at(6, "01:00"), want: false},
+		{name: "until midnight, before it", windows: []Window{untilMidnight}, t: at(6, "23:59"), want: true},
+		{name: "until midnight, after it", windows: []Window{untilMidnight}, t: at(7, "00:00"), want: false},
+		{name: "whole day, first minute", windows: []Window{wholeDay}, t: at(6, "00:00"), want: true},
+		{name: "whole day, last minute", windows: []Window{wholeDay}, t: at(6, "23:59"), want: true},
+		{name: "whole day, next day", windows: []Window{wholeDay}, t: at(7, "00:00"), want: false},
+		{name: "any of several", windows: []Window{untilMidnight, lateNight}, t: at(7, "01:00"), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InAnyWindow(tt.windows, tt.t, time.UTC); got != tt.want {
+				t.Fatalf("InAnyWindow at %s = %v, want %v", tt.t.Format("Mon 15:04"), got, tt.want)
+			}
+		})
+	}
+
+	t.Run("read in the given location", func(t *testing.T) {
+		// 23:00 Saturday in UTC+7 is 16:00 Saturday in UTC
+		loc := time.FixedZone("UTC+7", 7*60*60)
+		if !InAnyWindow([]Window{lateNight}, at(6, "16:00"), loc) {
+			t.Fatal("want 23:00 local time inside the window")
+		}
+		if InAnyWindow([]Window{lateNight}, at(6, "16:00"), time.UTC) {
+			t.Fatal("want 16:00 UTC outside the window")
+		}
+	})
+}
@@ -93,6 +93,16 @@ sleep 1
 # Start Workers (optional - comment out if not needed)
 echo -e "${GREEN}▶️  Starting Workers...${NC}"
 
+# Outbox Relay (publishes domain events written by the server)
+if [ -f bin/worker ]; then
+    ./bin/worker outbox > logs/worker-outbox.log 2>&1 &
+else
+    go run ./cmd/worker outbox > logs/worker-outbox.log 2>&1 &
+fi
+WORKER_OUTBOX_PID=$!
+echo "$WORKER_OUTBOX_PID" >> "$PIDS_FILE"
+echo -e "${GREEN}✅ Outbox Relay started (PID: $WORKER_OUTBOX_PID)${NC}"
+
 # Order Worker
 if [ -f bin/worker ]; then
     ./bin/worker order > logs/worker-order.log 2>&1 &